@@ -0,0 +1,339 @@
+package avltree
+
+import (
+	"cmp"
+	"errors"
+
+	"github.com/seonicklaus/data-structures-go/binarysearchtree"
+	"github.com/seonicklaus/data-structures-go/internal/traversal"
+)
+
+// node represents an immutable AVL Tree node, it holds its subtree height and size
+// so split and join never have to walk a subtree to rebalance it
+type node struct {
+	data   int
+	height int
+	size   uint
+	left   *node
+	right  *node
+}
+
+// AVLTree represents a height balanced Binary Search Tree built on split and join,
+// nodes are never modified once created so trees returned by Split, Join and the set
+// operations share structure with their inputs and the inputs stay usable
+type AVLTree struct {
+	root *node
+}
+
+var _ binarysearchtree.OrderedSet = (*AVLTree)(nil)
+
+func (t *AVLTree) Size() uint {
+	return size(t.root)
+}
+
+func (t *AVLTree) IsEmpty() bool {
+	return t.root == nil
+}
+
+func (t *AVLTree) GetHeight() int {
+	return height(t.root)
+}
+
+// Check if element is in tree, O(log n)
+func (t *AVLTree) Contains(element int) bool {
+	n := t.root
+
+	for n != nil {
+		direction := cmp.Compare(element, n.data)
+
+		if direction == 0 {
+			return true
+		}
+
+		if direction < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return false
+}
+
+// Add element into tree, O(log n)
+func (t *AVLTree) Add(element int) bool {
+	if t.Contains(element) {
+		return false
+	}
+
+	left, _, right := split(t.root, element)
+	t.root = join(left, element, right)
+
+	return true
+}
+
+// Remove element from tree, O(log n)
+func (t *AVLTree) Remove(element int) bool {
+	if !t.Contains(element) {
+		return false
+	}
+
+	left, _, right := split(t.root, element)
+	t.root = join2(left, right)
+
+	return true
+}
+
+// Split returns a tree holding the elements less than element and a tree holding the rest, O(log n)
+func (t *AVLTree) Split(element int) (*AVLTree, *AVLTree) {
+	left, found, right := split(t.root, element)
+
+	if found {
+		right = join(nil, element, right)
+	}
+
+	return &AVLTree{root: left}, &AVLTree{root: right}
+}
+
+// Join returns a tree holding the elements of a followed by the elements of b,
+// every element of a must be less than every element of b, O(log n)
+func Join(a, b *AVLTree) (*AVLTree, error) {
+	if a.IsEmpty() {
+		return &AVLTree{root: b.root}, nil
+	}
+
+	if b.IsEmpty() {
+		return &AVLTree{root: a.root}, nil
+	}
+
+	if cmp.Compare(maxNode(a.root).data, minNode(b.root).data) >= 0 {
+		return nil, errors.New("trees overlap")
+	}
+
+	return &AVLTree{root: join2(a.root, b.root)}, nil
+}
+
+// Union returns a tree holding the elements present in a or b, O(m log(n/m + 1))
+func Union(a, b *AVLTree) *AVLTree {
+	return &AVLTree{root: union(a.root, b.root)}
+}
+
+// Intersection returns a tree holding the elements present in both a and b, O(m log(n/m + 1))
+func Intersection(a, b *AVLTree) *AVLTree {
+	return &AVLTree{root: intersection(a.root, b.root)}
+}
+
+// Difference returns a tree holding the elements of a that are not in b, O(m log(n/m + 1))
+func Difference(a, b *AVLTree) *AVLTree {
+	return &AVLTree{root: difference(a.root, b.root)}
+}
+
+func (t *AVLTree) PrintTree(order string) ([]int, error) {
+	return traversal.PrintTree(t.root, order)
+}
+
+// newNode creates a node over two subtrees whose heights differ by at most one
+func newNode(left *node, data int, right *node) *node {
+	return &node{
+		data:   data,
+		height: max(height(left), height(right)) + 1,
+		size:   size(left) + size(right) + 1,
+		left:   left,
+		right:  right,
+	}
+}
+
+func rotateLeft(n *node) *node {
+	r := n.right
+	return newNode(newNode(n.left, n.data, r.left), r.data, r.right)
+}
+
+func rotateRight(n *node) *node {
+	l := n.left
+	return newNode(l.left, l.data, newNode(l.right, n.data, n.right))
+}
+
+// join returns a balanced tree of left, data and right, every element of left must be
+// less than data and every element of right greater, O(|height(left) - height(right)|)
+func join(left *node, data int, right *node) *node {
+	if height(left) > height(right)+1 {
+		return joinRight(left, data, right)
+	}
+
+	if height(right) > height(left)+1 {
+		return joinLeft(left, data, right)
+	}
+
+	return newNode(left, data, right)
+}
+
+// joinRight walks down the right spine of the taller left tree to attach right
+func joinRight(left *node, data int, right *node) *node {
+	if height(left.right) <= height(right)+1 {
+		joined := newNode(left.right, data, right)
+
+		if height(joined) <= height(left.left)+1 {
+			return newNode(left.left, left.data, joined)
+		}
+
+		return rotateLeft(newNode(left.left, left.data, rotateRight(joined)))
+	}
+
+	joined := joinRight(left.right, data, right)
+	result := newNode(left.left, left.data, joined)
+
+	if height(joined) <= height(left.left)+1 {
+		return result
+	}
+
+	return rotateLeft(result)
+}
+
+// joinLeft walks down the left spine of the taller right tree to attach left
+func joinLeft(left *node, data int, right *node) *node {
+	if height(right.left) <= height(left)+1 {
+		joined := newNode(left, data, right.left)
+
+		if height(joined) <= height(right.right)+1 {
+			return newNode(joined, right.data, right.right)
+		}
+
+		return rotateRight(newNode(rotateLeft(joined), right.data, right.right))
+	}
+
+	joined := joinLeft(left, data, right.left)
+	result := newNode(joined, right.data, right.right)
+
+	if height(joined) <= height(right.right)+1 {
+		return result
+	}
+
+	return rotateRight(result)
+}
+
+// join2 joins two trees without a middle element by pulling out the largest element of left
+func join2(left, right *node) *node {
+	if left == nil {
+		return right
+	}
+
+	rest, last := splitLast(left)
+	return join(rest, last, right)
+}
+
+func splitLast(n *node) (*node, int) {
+	if n.right == nil {
+		return n.left, n.data
+	}
+
+	rest, last := splitLast(n.right)
+	return join(n.left, n.data, rest), last
+}
+
+// split returns the elements less than element, whether element was present, and the elements greater
+func split(n *node, element int) (*node, bool, *node) {
+	if n == nil {
+		return nil, false, nil
+	}
+
+	direction := cmp.Compare(element, n.data)
+
+	if direction == 0 {
+		return n.left, true, n.right
+	}
+
+	if direction < 0 {
+		left, found, right := split(n.left, element)
+		return left, found, join(right, n.data, n.right)
+	}
+
+	left, found, right := split(n.right, element)
+	return join(n.left, n.data, left), found, right
+}
+
+func union(a, b *node) *node {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	left, _, right := split(b, a.data)
+	return join(union(a.left, left), a.data, union(a.right, right))
+}
+
+func intersection(a, b *node) *node {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	left, found, right := split(b, a.data)
+	leftResult := intersection(a.left, left)
+	rightResult := intersection(a.right, right)
+
+	if found {
+		return join(leftResult, a.data, rightResult)
+	}
+
+	return join2(leftResult, rightResult)
+}
+
+func difference(a, b *node) *node {
+	if a == nil {
+		return nil
+	}
+
+	if b == nil {
+		return a
+	}
+
+	left, _, right := split(a, b.data)
+	return join2(difference(left, b.left), difference(right, b.right))
+}
+
+func minNode(n *node) *node {
+	for n.left != nil {
+		n = n.left
+	}
+
+	return n
+}
+
+func maxNode(n *node) *node {
+	for n.right != nil {
+		n = n.right
+	}
+
+	return n
+}
+
+func height(n *node) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+func size(n *node) uint {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+// Value and Children let the traversal package walk the tree
+func (n *node) Value() int {
+	return n.data
+}
+
+func (n *node) Children() (*node, *node) {
+	return n.left, n.right
+}
+
+func NewTree() *AVLTree {
+	return &AVLTree{}
+}
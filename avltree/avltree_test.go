@@ -0,0 +1,213 @@
+package avltree
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// checkNode checks the stored height and size, the balance and the order of a subtree
+// whose elements lie strictly between low and high, returns its elements in order
+func checkNode(t *testing.T, n *node, low, high *int) []int {
+	t.Helper()
+
+	if n == nil {
+		return nil
+	}
+
+	if (low != nil && n.data <= *low) || (high != nil && n.data >= *high) {
+		t.Fatalf("node %d is out of order", n.data)
+	}
+
+	if balance := height(n.left) - height(n.right); balance < -1 || balance > 1 {
+		t.Fatalf("node %d has balance %d", n.data, balance)
+	}
+
+	if n.height != max(height(n.left), height(n.right))+1 {
+		t.Fatalf("node %d stores height %d", n.data, n.height)
+	}
+
+	if n.size != size(n.left)+size(n.right)+1 {
+		t.Fatalf("node %d stores size %d", n.data, n.size)
+	}
+
+	elements := checkNode(t, n.left, low, &n.data)
+	elements = append(elements, n.data)
+
+	return append(elements, checkNode(t, n.right, &n.data, high)...)
+}
+
+// checkTree checks that the tree is a valid AVL tree holding exactly the elements of want
+func checkTree(t *testing.T, tree *AVLTree, want map[int]bool) {
+	t.Helper()
+
+	if got, sorted := checkNode(t, tree.root, nil, nil), slices.Sorted(maps.Keys(want)); !slices.Equal(got, sorted) {
+		t.Fatalf("tree holds %v, want %v", got, sorted)
+	}
+
+	if tree.Size() != uint(len(want)) {
+		t.Fatalf("Size() = %d, want %d", tree.Size(), len(want))
+	}
+}
+
+// randomSet returns a tree and a map holding the same random elements of [0, span)
+func randomSet(r *rand.Rand, n, span int) (*AVLTree, map[int]bool) {
+	tree := NewTree()
+	set := make(map[int]bool)
+
+	for i := 0; i < n; i++ {
+		v := r.IntN(span)
+		tree.Add(v)
+		set[v] = true
+	}
+
+	return tree, set
+}
+
+func TestAddRemoveStayBalanced(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	tree, set := NewTree(), make(map[int]bool)
+
+	for i := 0; i < 2000; i++ {
+		v := r.IntN(300)
+
+		if r.IntN(3) == 0 {
+			if tree.Remove(v) != set[v] {
+				t.Fatalf("Remove(%d) disagrees with the reference", v)
+			}
+
+			delete(set, v)
+		} else {
+			if tree.Add(v) == set[v] {
+				t.Fatalf("Add(%d) disagrees with the reference", v)
+			}
+
+			set[v] = true
+		}
+
+		checkTree(t, tree, set)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+
+	for round := 0; round < 200; round++ {
+		a, aSet := randomSet(r, r.IntN(80), 120)
+		b, bSet := randomSet(r, r.IntN(80), 120)
+		aRoot, bRoot := a.root, b.root
+
+		union, intersection, difference := make(map[int]bool), make(map[int]bool), make(map[int]bool)
+
+		for v := range aSet {
+			union[v] = true
+
+			if bSet[v] {
+				intersection[v] = true
+			} else {
+				difference[v] = true
+			}
+		}
+
+		maps.Copy(union, bSet)
+
+		checkTree(t, Union(a, b), union)
+		checkTree(t, Intersection(a, b), intersection)
+		checkTree(t, Difference(a, b), difference)
+
+		// The inputs share nodes with the results but are never modified
+		if a.root != aRoot || b.root != bRoot {
+			t.Fatal("set operation replaced an input root")
+		}
+
+		checkTree(t, a, aSet)
+		checkTree(t, b, bSet)
+	}
+}
+
+func TestSplitAndJoin(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+
+	for round := 0; round < 200; round++ {
+		tree, set := randomSet(r, r.IntN(100), 150)
+		root := tree.root
+		x := r.IntN(160) - 5
+
+		left, right := tree.Split(x)
+		leftSet, rightSet := make(map[int]bool), make(map[int]bool)
+
+		for v := range set {
+			if v < x {
+				leftSet[v] = true
+			} else {
+				rightSet[v] = true
+			}
+		}
+
+		// x itself goes to the right tree
+		checkTree(t, left, leftSet)
+		checkTree(t, right, rightSet)
+
+		if right.Contains(x) != set[x] || left.Contains(x) {
+			t.Fatalf("Split(%d) put it on the wrong side", x)
+		}
+
+		joined, err := Join(left, right)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		checkTree(t, joined, set)
+
+		if tree.root != root {
+			t.Fatal("Split replaced the root of its input")
+		}
+
+		checkTree(t, tree, set)
+	}
+}
+
+func TestJoinRejectsOverlappingTrees(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []int
+	}{
+		{"interleaved", []int{1, 5, 9}, []int{3, 7}},
+		{"shared boundary", []int{1, 2, 3}, []int{3, 4}},
+		{"wrong order", []int{5, 6}, []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := NewTree(), NewTree()
+
+			for _, v := range tt.a {
+				a.Add(v)
+			}
+
+			for _, v := range tt.b {
+				b.Add(v)
+			}
+
+			if joined, err := Join(a, b); err == nil {
+				t.Errorf("Join returned %v with no error", joined)
+			}
+		})
+	}
+}
+
+func TestJoinWithEmptyTree(t *testing.T) {
+	tree := NewTree()
+	tree.Add(4)
+	tree.Add(2)
+
+	for _, pair := range [][2]*AVLTree{{tree, NewTree()}, {NewTree(), tree}} {
+		joined, err := Join(pair[0], pair[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		checkTree(t, joined, map[int]bool{2: true, 4: true})
+	}
+}
@@ -0,0 +1,146 @@
+package binarysearchtree
+
+import (
+	"errors"
+)
+
+// LowestCommonAncestor returns the deepest element that has both a and b in its subtree, O(h)
+func (bst *BinarySearchTree) LowestCommonAncestor(a, b int) (int, error) {
+	lca, err := bst.lowestCommonAncestor(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	return lca.data, nil
+}
+
+// PathTo returns the elements on the path from the root down to element, O(h)
+func (bst *BinarySearchTree) PathTo(element int) ([]int, error) {
+	if !bst.Contains(element) {
+		return nil, errors.New("element not found in tree")
+	}
+
+	return pathFrom(bst.root, element), nil
+}
+
+// Depth returns the number of edges between the root and element, O(h)
+func (bst *BinarySearchTree) Depth(element int) (int, error) {
+	if !bst.Contains(element) {
+		return 0, errors.New("element not found in tree")
+	}
+
+	return len(pathFrom(bst.root, element)) - 1, nil
+}
+
+// KthAncestor returns the element k levels above element, the element itself when k is 0, O(h)
+func (bst *BinarySearchTree) KthAncestor(element int, k int) (int, error) {
+	path, err := bst.PathTo(element)
+	if err != nil {
+		return 0, err
+	}
+
+	if k < 0 || k >= len(path) {
+		return 0, errors.New("ancestor out of range")
+	}
+
+	return path[len(path)-1-k], nil
+}
+
+// Distance returns the number of edges on the path between a and b, O(h)
+func (bst *BinarySearchTree) Distance(a, b int) (int, error) {
+	lca, err := bst.lowestCommonAncestor(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(pathFrom(lca, a)) + len(pathFrom(lca, b)) - 2, nil
+}
+
+// Successor returns the smallest element greater than element, O(h)
+func (bst *BinarySearchTree) Successor(element int) (int, error) {
+	if !bst.Contains(element) {
+		return 0, errors.New("element not found in tree")
+	}
+
+	var successor *node
+
+	for n := bst.root; n != nil; {
+		if compareTo(element, n.data) < 0 {
+			successor = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	if successor == nil {
+		return 0, errors.New("element has no successor")
+	}
+
+	return successor.data, nil
+}
+
+// Predecessor returns the largest element less than element, O(h)
+func (bst *BinarySearchTree) Predecessor(element int) (int, error) {
+	if !bst.Contains(element) {
+		return 0, errors.New("element not found in tree")
+	}
+
+	var predecessor *node
+
+	for n := bst.root; n != nil; {
+		if compareTo(element, n.data) > 0 {
+			predecessor = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+
+	if predecessor == nil {
+		return 0, errors.New("element has no predecessor")
+	}
+
+	return predecessor.data, nil
+}
+
+// lowestCommonAncestor descends while a and b are on the same side, the node where they split is the answer
+func (bst *BinarySearchTree) lowestCommonAncestor(a, b int) (*node, error) {
+	if !bst.Contains(a) || !bst.Contains(b) {
+		return nil, errors.New("element not found in tree")
+	}
+
+	n := bst.root
+
+	for {
+		if compareTo(a, n.data) < 0 && compareTo(b, n.data) < 0 {
+			n = n.left
+		} else if compareTo(a, n.data) > 0 && compareTo(b, n.data) > 0 {
+			n = n.right
+		} else {
+			return n, nil
+		}
+	}
+}
+
+// pathFrom returns the elements from n down to element, element must be in the subtree of n
+func pathFrom(n *node, element int) []int {
+	var path []int
+
+	for n != nil {
+		path = append(path, n.data)
+		cmp := compareTo(element, n.data)
+
+		if cmp == 0 {
+			break
+		}
+
+		if cmp < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return path
+}
@@ -0,0 +1,194 @@
+package binarysearchtree
+
+import (
+	"slices"
+	"testing"
+)
+
+const (
+	errNotFound      = "element not found in tree"
+	errOutOfRange    = "ancestor out of range"
+	errNoSuccessor   = "element has no successor"
+	errNoPredecessor = "element has no predecessor"
+)
+
+// ancestryTree returns the perfect tree over 1..15
+//
+//	              8
+//	      4               12
+//	  2       6       10      14
+//	1   3   5   7   9   11  13  15
+func ancestryTree() *BinarySearchTree {
+	tree, _ := FromSorted([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+	return tree
+}
+
+// checkResult compares a result and error with the wanted value or error message
+func checkResult[T comparable](t *testing.T, got T, err error, want T, wantErr string) {
+	t.Helper()
+
+	if wantErr != "" {
+		if err == nil || err.Error() != wantErr {
+			t.Errorf("error = %v, want %q", err, wantErr)
+		}
+
+		return
+	}
+
+	if err != nil || got != want {
+		t.Errorf("got %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestLowestCommonAncestorAndDistance(t *testing.T) {
+	bst := ancestryTree()
+
+	tests := []struct {
+		name     string
+		a, b     int
+		lca      int
+		distance int
+		wantErr  string
+	}{
+		{"same element", 5, 5, 5, 0, ""},
+		{"root and leaf", 8, 13, 8, 3, ""},
+		{"ancestor and descendant", 4, 7, 4, 2, ""},
+		{"descendant and ancestor", 7, 4, 4, 2, ""},
+		{"siblings", 9, 11, 10, 2, ""},
+		{"opposite subtrees", 1, 15, 8, 6, ""},
+		{"first absent", 0, 3, 0, 0, errNotFound},
+		{"second absent", 3, 16, 0, 0, errNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lca, err := bst.LowestCommonAncestor(tt.a, tt.b)
+			checkResult(t, lca, err, tt.lca, tt.wantErr)
+
+			distance, err := bst.Distance(tt.a, tt.b)
+			checkResult(t, distance, err, tt.distance, tt.wantErr)
+		})
+	}
+}
+
+func TestPathToAndDepth(t *testing.T) {
+	bst := ancestryTree()
+
+	tests := []struct {
+		name    string
+		element int
+		path    []int
+		wantErr string
+	}{
+		{"root", 8, []int{8}, ""},
+		{"inner node", 6, []int{8, 4, 6}, ""},
+		{"leaf", 11, []int{8, 12, 10, 11}, ""},
+		{"absent", 100, nil, errNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := bst.PathTo(tt.element)
+
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr || path != nil {
+					t.Errorf("PathTo = %v, %v, want error %q", path, err, tt.wantErr)
+				}
+			} else if err != nil || !slices.Equal(path, tt.path) {
+				t.Errorf("PathTo = %v, %v, want %v", path, err, tt.path)
+			}
+
+			depth, err := bst.Depth(tt.element)
+			checkResult(t, depth, err, len(tt.path)-1, tt.wantErr)
+		})
+	}
+}
+
+func TestKthAncestor(t *testing.T) {
+	bst := ancestryTree()
+
+	tests := []struct {
+		name     string
+		element  int
+		k        int
+		ancestor int
+		wantErr  string
+	}{
+		{"itself", 9, 0, 9, ""},
+		{"parent", 9, 1, 10, ""},
+		{"root from a leaf", 9, 3, 8, ""},
+		{"root itself", 8, 0, 8, ""},
+		{"above the root", 9, 4, 0, errOutOfRange},
+		{"above the root from the root", 8, 1, 0, errOutOfRange},
+		{"negative", 9, -1, 0, errOutOfRange},
+		{"absent", 16, 0, 0, errNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ancestor, err := bst.KthAncestor(tt.element, tt.k)
+			checkResult(t, ancestor, err, tt.ancestor, tt.wantErr)
+		})
+	}
+}
+
+func TestSuccessorAndPredecessor(t *testing.T) {
+	bst := ancestryTree()
+
+	tests := []struct {
+		name           string
+		element        int
+		successor      int
+		successorErr   string
+		predecessor    int
+		predecessorErr string
+	}{
+		{"root", 8, 9, "", 7, ""},
+		{"leaf below a left turn", 7, 8, "", 6, ""},
+		{"inner node", 12, 13, "", 11, ""},
+		{"minimum", 1, 2, "", 0, errNoPredecessor},
+		{"maximum", 15, 0, errNoSuccessor, 14, ""},
+		{"absent", 0, 0, errNotFound, 0, errNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			successor, err := bst.Successor(tt.element)
+			checkResult(t, successor, err, tt.successor, tt.successorErr)
+
+			predecessor, err := bst.Predecessor(tt.element)
+			checkResult(t, predecessor, err, tt.predecessor, tt.predecessorErr)
+		})
+	}
+
+	single := NewTree()
+	single.Add(4)
+
+	if _, err := single.Successor(4); err == nil || err.Error() != errNoSuccessor {
+		t.Errorf("Successor on a single node = %v, want %q", err, errNoSuccessor)
+	}
+
+	if _, err := single.Predecessor(4); err == nil || err.Error() != errNoPredecessor {
+		t.Errorf("Predecessor on a single node = %v, want %q", err, errNoPredecessor)
+	}
+}
+
+// Every query on an empty tree reports the element as not found
+func TestAncestryOnEmptyTree(t *testing.T) {
+	bst := NewTree()
+	queries := map[string]func() error{
+		"LowestCommonAncestor": func() error { _, err := bst.LowestCommonAncestor(1, 1); return err },
+		"PathTo":               func() error { _, err := bst.PathTo(1); return err },
+		"Depth":                func() error { _, err := bst.Depth(1); return err },
+		"KthAncestor":          func() error { _, err := bst.KthAncestor(1, 0); return err },
+		"Distance":             func() error { _, err := bst.Distance(1, 1); return err },
+		"Successor":            func() error { _, err := bst.Successor(1); return err },
+		"Predecessor":          func() error { _, err := bst.Predecessor(1); return err },
+	}
+
+	for name, query := range queries {
+		if err := query(); err == nil || err.Error() != errNotFound {
+			t.Errorf("%s on empty tree = %v, want %q", name, err, errNotFound)
+		}
+	}
+}
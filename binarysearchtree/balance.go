@@ -0,0 +1,106 @@
+package binarysearchtree
+
+import (
+	"errors"
+	"math/bits"
+	"slices"
+)
+
+// FromSorted builds a height optimal tree from strictly increasing items, O(n)
+func FromSorted(items []int) (*BinarySearchTree, error) {
+	for i := 1; i < len(items); i++ {
+		if items[i-1] >= items[i] {
+			return nil, errors.New("items are not strictly increasing")
+		}
+	}
+
+	return &BinarySearchTree{root: buildBalanced(items), nodeCount: uint(len(items))}, nil
+}
+
+// FromSlice sorts and removes duplicates from a copy of items before building a height optimal tree, O(n log n)
+func FromSlice(items []int) *BinarySearchTree {
+	sorted := slices.Clone(items)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	tree, _ := FromSorted(sorted)
+	return tree
+}
+
+// buildBalanced makes the middle item the root so both halves differ in size by at most one
+func buildBalanced(items []int) *node {
+	if len(items) == 0 {
+		return nil
+	}
+
+	middle := len(items) / 2
+
+	return &node{
+		data:  items[middle],
+		count: 1,
+		left:  buildBalanced(items[:middle]),
+		right: buildBalanced(items[middle+1:]),
+	}
+}
+
+// Rebalance rebuilds the tree in place into a height optimal shape without extra memory
+// (Day-Stout-Warren), O(n). Cursors created before rebalancing stop with an error
+func (bst *BinarySearchTree) Rebalance() {
+	bst.guard()
+
+	pseudoRoot := &node{right: bst.root}
+
+	size := treeToVine(pseudoRoot)
+	vineToTree(pseudoRoot, size)
+
+	bst.root = pseudoRoot.right
+	bst.modCount++
+}
+
+// treeToVine rotates every left child up until the tree is a right leaning list, returns the number of nodes
+func treeToVine(pseudoRoot *node) int {
+	tail := pseudoRoot
+	rest := tail.right
+	size := 0
+
+	for rest != nil {
+		if rest.left == nil {
+			tail = rest
+			rest = rest.right
+			size++
+		} else {
+			temp := rest.left
+			rest.left = temp.right
+			temp.right = rest
+			rest = temp
+			tail.right = temp
+		}
+	}
+
+	return size
+}
+
+// vineToTree folds the vine with left rotations, first filling the bottom level so it ends up complete
+func vineToTree(pseudoRoot *node, size int) {
+	leaves := size + 1 - 1<<(bits.Len(uint(size+1))-1)
+	compress(pseudoRoot, leaves)
+	size -= leaves
+
+	for size > 1 {
+		size /= 2
+		compress(pseudoRoot, size)
+	}
+}
+
+// compress left rotates every other node along the vine, count times
+func compress(pseudoRoot *node, count int) {
+	scanner := pseudoRoot
+
+	for i := 0; i < count; i++ {
+		child := scanner.right
+		scanner.right = child.right
+		scanner = scanner.right
+		child.right = scanner.left
+		scanner.left = child
+	}
+}
@@ -0,0 +1,130 @@
+package binarysearchtree
+
+import (
+	"fmt"
+	"maps"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// balanceSizes returns tree sizes covering every n up to 70 and both sides of powers of two
+func balanceSizes() []int {
+	var sizes []int
+
+	for n := 0; n <= 70; n++ {
+		sizes = append(sizes, n)
+	}
+
+	for k := 7; k <= 11; k++ {
+		sizes = append(sizes, 1<<k-1, 1<<k, 1<<k+1)
+	}
+
+	return sizes
+}
+
+// checkOptimal checks a height optimal tree holding 1..n
+func checkOptimal(t *testing.T, bst *BinarySearchTree, n int) {
+	t.Helper()
+
+	if got, want := bst.GetHeight(), bits.Len(uint(n)); got != want {
+		t.Errorf("n = %d: GetHeight() = %d, want %d", n, got, want)
+	}
+
+	if !bst.IsValid() || !bst.IsBalanced() || bst.Size() != uint(n) {
+		t.Errorf("n = %d: tree is invalid or unbalanced, Size() = %d", n, bst.Size())
+	}
+
+	if got := slices.Collect(bst.Inorder()); !slices.Equal(got, sequence(n)) {
+		t.Errorf("n = %d: inorder = %v", n, got)
+	}
+}
+
+// sequence returns 1..n
+func sequence(n int) []int {
+	items := make([]int, n)
+
+	for i := range items {
+		items[i] = i + 1
+	}
+
+	return items
+}
+
+func TestFromSortedIsHeightOptimal(t *testing.T) {
+	for _, n := range balanceSizes() {
+		bst, err := FromSorted(sequence(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		checkOptimal(t, bst, n)
+	}
+}
+
+func TestFromSortedRejectsUnsortedInput(t *testing.T) {
+	for _, items := range [][]int{{2, 1}, {1, 2, 2, 3}, {1, 3, 2, 4}, {5, 5}} {
+		if _, err := FromSorted(items); err == nil {
+			t.Errorf("FromSorted(%v) returned no error", items)
+		}
+	}
+}
+
+func TestRebalanceIsHeightOptimal(t *testing.T) {
+	r := rand.New(rand.NewPCG(2, 9))
+
+	for _, n := range balanceSizes() {
+		descending := sequence(n)
+		slices.Reverse(descending)
+
+		random := sequence(n)
+		r.Shuffle(n, func(i, j int) {
+			random[i], random[j] = random[j], random[i]
+		})
+
+		shapes := map[string][]int{"ascending": sequence(n), "descending": descending, "random": random}
+
+		for name, items := range shapes {
+			bst := NewTree()
+
+			for _, v := range items {
+				bst.Add(v)
+			}
+
+			bst.Rebalance()
+			t.Run(fmt.Sprintf("%s/%d", name, n), func(t *testing.T) { checkOptimal(t, bst, n) })
+		}
+	}
+}
+
+// Rebalancing a degenerate multiset keeps every element with its number of occurrences
+func TestRebalanceKeepsMultisetCounts(t *testing.T) {
+	bst := NewMultiset()
+	counts := make(map[int]uint)
+
+	for v := 1; v <= 100; v++ {
+		for i := 0; i <= v%4; i++ {
+			bst.Add(v)
+			counts[v]++
+		}
+	}
+
+	before, _ := bst.Collect(InOrder)
+	bst.Rebalance()
+	after, _ := bst.Collect(InOrder)
+
+	if !slices.Equal(before, after) {
+		t.Errorf("inorder changed from %v to %v", before, after)
+	}
+
+	for _, v := range slices.Sorted(maps.Keys(counts)) {
+		if bst.Count(v) != counts[v] {
+			t.Errorf("Count(%d) = %d, want %d", v, bst.Count(v), counts[v])
+		}
+	}
+
+	if !bst.IsValid() || bst.GetHeight() != bits.Len(100) {
+		t.Errorf("rebalanced multiset is invalid or has height %d", bst.GetHeight())
+	}
+}
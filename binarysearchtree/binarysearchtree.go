@@ -1,18 +1,23 @@
 package binarysearchtree
 
-import (
-	"errors"
-)
-
 type node struct {
 	data  int
+	count uint
 	left  *node
 	right *node
 }
 
+// BinarySearchTree keeps modCount, bumped on every change to the content or shape of the
+// tree, so cursors and walks detect modification even when the size is unchanged, shared
+// marks a read-only view over nodes that other versions or goroutines may read, threading
+// is set while a Morris walk has threaded the nodes
 type BinarySearchTree struct {
 	root      *node
 	nodeCount uint
+	multiset  bool
+	modCount  uint64
+	shared    bool
+	threading uint32
 }
 
 type stack struct {
@@ -20,11 +25,6 @@ type stack struct {
 	size  int
 }
 
-type queue struct {
-	items []*node
-	size  int
-}
-
 func (bst *BinarySearchTree) Size() uint {
 	return bst.nodeCount
 }
@@ -34,24 +34,55 @@ func (bst *BinarySearchTree) IsEmpty() bool {
 }
 
 func (bst *BinarySearchTree) Contains(element int) bool {
+	bst.guard()
+
 	return bst.contains(bst.root, element)
 }
 
+// Count returns the number of occurrences of element, at most 1 unless the tree is a multiset
+func (bst *BinarySearchTree) Count(element int) uint {
+	bst.guard()
+
+	if n := bst.find(element); n != nil {
+		return n.count
+	}
+
+	return 0
+}
+
 func (bst *BinarySearchTree) Add(element int) bool {
 
-	if bst.Contains(element) {
-		return false
+	bst.guard()
+
+	if n := bst.find(element); n != nil {
+		if !bst.multiset {
+			return false
+		}
+
+		n.count++
+		bst.nodeCount++
+		bst.modCount++
+		return true
 	} else {
 		bst.root = bst.add(bst.root, element)
 		bst.nodeCount++
+		bst.modCount++
 		return true
 	}
 }
 
 func (bst *BinarySearchTree) Remove(element int) bool {
-	if bst.Contains(element) {
-		bst.root = bst.remove(bst.root, element)
+	bst.guard()
+
+	if n := bst.find(element); n != nil {
+		if n.count > 1 {
+			n.count--
+		} else {
+			bst.root = bst.remove(bst.root, element)
+		}
+
 		bst.nodeCount--
+		bst.modCount++
 		return true
 	}
 
@@ -59,13 +90,15 @@ func (bst *BinarySearchTree) Remove(element int) bool {
 }
 
 func (bst *BinarySearchTree) GetHeight() int {
+	bst.guard()
+
 	return bst.height(bst.root)
 }
 
 func (bst *BinarySearchTree) add(n *node, element int) *node {
 
 	if n == nil {
-		n = &node{data: element}
+		n = &node{data: element, count: 1}
 	} else {
 
 		if compareTo(element, n.data) > 0 {
@@ -78,19 +111,17 @@ func (bst *BinarySearchTree) add(n *node, element int) *node {
 	return n
 }
 
+// PrintTree returns every element in the order named by one of "preorder", "inorder", "postorder",
+// "levelorder", "morris-inorder" or "morris-preorder", see Collect for the typed equivalent. The
+// Morris orders write to the nodes while they run, so they are not safe alongside other readers
+// and return ErrModified when another Morris walk is running
 func (bst *BinarySearchTree) PrintTree(order string) ([]int, error) {
-	switch order {
-	case "preorder":
-		return bst.preorder()
-	case "inorder":
-		return bst.inorder()
-	case "postorder":
-		return bst.postorder()
-	case "levelorder":
-		return bst.levelorder()
-	default:
-		return nil, errors.New("order is invalid")
+	parsed, err := ParseOrder(order)
+	if err != nil {
+		return nil, err
 	}
+
+	return bst.Collect(parsed)
 }
 
 func (bst *BinarySearchTree) remove(n *node, element int) *node {
@@ -111,6 +142,7 @@ func (bst *BinarySearchTree) remove(n *node, element int) *node {
 			rightChild := n.right
 
 			n.data = 0
+			n.count = 0
 			n = nil
 
 			return rightChild
@@ -118,12 +150,14 @@ func (bst *BinarySearchTree) remove(n *node, element int) *node {
 			leftChild := n.left
 
 			n.data = 0
+			n.count = 0
 			n = nil
 
 			return leftChild
 		} else {
 			smallestRight := bst.digLeft(n.right)
 			n.data = smallestRight.data
+			n.count = smallestRight.count
 			n.right = bst.remove(n.right, smallestRight.data)
 		}
 	}
@@ -159,6 +193,27 @@ func (bst *BinarySearchTree) height(node *node) int {
 	return max(bst.height(node.left), bst.height(node.right)) + 1
 }
 
+// find returns the node holding element, nil otherwise, O(h)
+func (bst *BinarySearchTree) find(element int) *node {
+	n := bst.root
+
+	for n != nil {
+		cmp := compareTo(element, n.data)
+
+		if cmp == 0 {
+			return n
+		}
+
+		if cmp < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return nil
+}
+
 func (bst *BinarySearchTree) contains(node *node, element int) bool {
 
 	if node == nil {
@@ -183,11 +238,6 @@ func (s *stack) push(node *node) {
 	s.size++
 }
 
-func (q *queue) enqueue(node *node) {
-	q.items = append(q.items, node)
-	q.size++
-}
-
 func (s *stack) pop() *node {
 	removedData := s.items[s.size-1]
 	s.items = s.items[:s.size-1]
@@ -195,141 +245,14 @@ func (s *stack) pop() *node {
 	return removedData
 }
 
-func (q *queue) dequeue() *node {
-	removedData := q.items[0]
-	q.items = q.items[1:]
-	q.size--
-	return removedData
+func (s *stack) peek() *node {
+	return s.items[s.size-1]
 }
 
 func (s *stack) isEmpty() bool {
 	return s.size == 0
 }
 
-func (q *queue) isEmpty() bool {
-	return q.size == 0
-}
-
-func (bst *BinarySearchTree) preorder() ([]int, error) {
-	expectedNodeCount := bst.nodeCount
-	stack := stack{}
-	stack.push(bst.root)
-	var data []int
-
-	for bst.root != nil && !stack.isEmpty() {
-
-		if expectedNodeCount != bst.nodeCount {
-			return nil, errors.New("modification detected during iteration")
-		}
-
-		node := stack.pop()
-
-		if node.right != nil {
-			stack.push(node.right)
-		}
-
-		if node.left != nil {
-			stack.push(node.left)
-		}
-
-		data = append(data, node.data)
-	}
-
-	return data, nil
-}
-
-func (bst *BinarySearchTree) inorder() ([]int, error) {
-	expectedNodeCount := bst.nodeCount
-	stack := stack{}
-	stack.push(bst.root)
-	travNode := bst.root
-	var data []int
-
-	for bst.root != nil && !stack.isEmpty() {
-
-		if expectedNodeCount != bst.nodeCount {
-			return nil, errors.New("modification detected during iteration")
-		}
-
-		for ; travNode.left != nil; travNode = travNode.left {
-			stack.push(travNode.left)
-		}
-
-		node := stack.pop()
-		data = append(data, node.data)
-
-		if node.right != nil {
-			stack.push(node.right)
-			travNode = node.right
-		}
-	}
-
-	return data, nil
-}
-
-func (bst *BinarySearchTree) postorder() ([]int, error) {
-	expectedNodeCount := bst.nodeCount
-	stack1 := stack{}
-	stack2 := stack{}
-	stack1.push(bst.root)
-	var data []int
-
-	for !stack1.isEmpty() {
-		node := stack1.pop()
-
-		if node != nil {
-			stack2.push(node)
-
-			if node.left != nil {
-				stack1.push(node.left)
-			}
-			if node.right != nil {
-				stack1.push(node.right)
-			}
-		}
-	}
-
-	for bst.root != nil && !stack2.isEmpty() {
-
-		if expectedNodeCount != bst.nodeCount {
-			return nil, errors.New("modification detected during iteration")
-		}
-
-		node := stack2.pop()
-		data = append(data, node.data)
-
-	}
-
-	return data, nil
-}
-
-func (bst *BinarySearchTree) levelorder() ([]int, error) {
-	expectedNodeCount := bst.nodeCount
-	queue := queue{}
-	queue.enqueue(bst.root)
-	var data []int
-
-	for bst.root != nil && !queue.isEmpty() {
-
-		if expectedNodeCount != bst.nodeCount {
-			return nil, errors.New("modification detected during iteration")
-		}
-
-		node := queue.dequeue()
-
-		if node.left != nil {
-			queue.enqueue(node.left)
-		}
-
-		if node.right != nil {
-			queue.enqueue(node.right)
-		}
-
-		data = append(data, node.data)
-	}
-	return data, nil
-}
-
 func max(x, y int) int {
 	if x > y {
 		return x
@@ -351,3 +274,8 @@ func compareTo(x, y int) int {
 func NewTree() *BinarySearchTree {
 	return &BinarySearchTree{}
 }
+
+// NewMultiset returns a tree that keeps duplicate elements, counting occurrences per node
+func NewMultiset() *BinarySearchTree {
+	return &BinarySearchTree{multiset: true}
+}
@@ -0,0 +1,89 @@
+package binarysearchtree
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentTree represents a Binary Search Tree safe for concurrent use, readers work on
+// an immutable snapshot and never block, writers are serialized and publish each new
+// version with an atomic swap of the root so readers never observe a partial update
+type ConcurrentTree struct {
+	mu       sync.Mutex
+	snapshot atomic.Pointer[PersistentTree]
+}
+
+var _ OrderedSet = (*ConcurrentTree)(nil)
+
+// Snapshot returns the current version, it stays unchanged while writers move on
+func (ct *ConcurrentTree) Snapshot() *PersistentTree {
+	if snapshot := ct.snapshot.Load(); snapshot != nil {
+		return snapshot
+	}
+
+	return NewPersistentTree()
+}
+
+func (ct *ConcurrentTree) Size() uint {
+	return ct.Snapshot().Size()
+}
+
+func (ct *ConcurrentTree) IsEmpty() bool {
+	return ct.Snapshot().IsEmpty()
+}
+
+func (ct *ConcurrentTree) Contains(element int) bool {
+	return ct.Snapshot().Contains(element)
+}
+
+func (ct *ConcurrentTree) GetHeight() int {
+	return ct.Snapshot().GetHeight()
+}
+
+// PrintTree traverses a single snapshot, so concurrent writers never cause a modification error
+func (ct *ConcurrentTree) PrintTree(order string) ([]int, error) {
+	return ct.Snapshot().PrintTree(order)
+}
+
+// Add element and publish the new version, O(h)
+func (ct *ConcurrentTree) Add(element int) bool {
+	added := false
+
+	ct.Update(func(current *PersistentTree) *PersistentTree {
+		next, ok := current.Add(element)
+		added = ok
+		return next
+	})
+
+	return added
+}
+
+// Remove element and publish the new version, O(h)
+func (ct *ConcurrentTree) Remove(element int) bool {
+	removed := false
+
+	ct.Update(func(current *PersistentTree) *PersistentTree {
+		next, ok := current.Remove(element)
+		removed = ok
+		return next
+	})
+
+	return removed
+}
+
+// Update applies several changes as one, readers see either none or all of them
+func (ct *ConcurrentTree) Update(update func(current *PersistentTree) *PersistentTree) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	current := ct.Snapshot()
+	next := update(current)
+
+	if next != current {
+		ct.snapshot.Store(next)
+	}
+}
+
+func NewConcurrentTree() *ConcurrentTree {
+	return &ConcurrentTree{}
+}
@@ -0,0 +1,176 @@
+package binarysearchtree
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+// pairOffset pairs every element below it with element+pairOffset, writers always add and
+// remove both in one Update so readers must never see one without the other
+const pairOffset = 1000
+
+func TestConcurrentTreeReadersAndWriters(t *testing.T) {
+	ct := NewConcurrentTree()
+	orders := []string{"preorder", "inorder", "postorder", "levelorder", "morris-inorder", "morris-preorder"}
+
+	var writers, readers sync.WaitGroup
+	done := make(chan struct{})
+
+	for w := 0; w < 4; w++ {
+		writers.Add(1)
+
+		go func(w int) {
+			defer writers.Done()
+
+			for i := 0; i < 500; i++ {
+				element := (w*500 + i*7) % pairOffset
+
+				ct.Update(func(current *PersistentTree) *PersistentTree {
+					if current.Contains(element) {
+						next, _ := current.Remove(element)
+						next, _ = next.Remove(element + pairOffset)
+						return next
+					}
+
+					next, _ := current.Add(element)
+					next, _ = next.Add(element + pairOffset)
+					return next
+				})
+			}
+		}(w)
+	}
+
+	for r := 0; r < 8; r++ {
+		readers.Add(1)
+
+		go func(r int) {
+			defer readers.Done()
+
+			for i := 0; ; i++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				order := orders[(r+i)%len(orders)]
+
+				data, err := ct.PrintTree(order)
+				if err != nil {
+					t.Errorf("PrintTree(%q): %v", order, err)
+					return
+				}
+
+				if !pairsComplete(data) {
+					t.Errorf("PrintTree(%q) = %v, saw a partial update", order, data)
+					return
+				}
+
+				if order == "inorder" && !slices.IsSorted(data) {
+					t.Errorf("PrintTree(%q) = %v, not sorted", order, data)
+					return
+				}
+
+				snapshot := ct.Snapshot()
+				inorder := slices.Collect(snapshot.Ascending())
+
+				if uint(len(inorder)) != snapshot.Size() {
+					t.Errorf("snapshot holds %d elements, Size() = %d", len(inorder), snapshot.Size())
+					return
+				}
+			}
+		}(r)
+	}
+
+	writers.Wait()
+	close(done)
+	readers.Wait()
+
+	data, _ := ct.PrintTree("inorder")
+
+	if !pairsComplete(data) {
+		t.Fatalf("final version %v holds an element without its pair", data)
+	}
+
+	if !ct.Snapshot().view().IsValid() {
+		t.Fatal("final version is not a valid Binary Search Tree")
+	}
+}
+
+func TestConcurrentTreeAddRemove(t *testing.T) {
+	ct := NewConcurrentTree()
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < 200; i++ {
+				ct.Add(w*200 + i)
+			}
+
+			for i := 0; i < 200; i += 2 {
+				ct.Remove(w*200 + i)
+			}
+		}(w)
+	}
+
+	wg.Wait()
+
+	if ct.Size() != 800 {
+		t.Fatalf("Size() = %d, want 800", ct.Size())
+	}
+
+	for element := 0; element < 1600; element++ {
+		if ct.Contains(element) != (element%2 == 1) {
+			t.Fatalf("Contains(%d) = %v", element, ct.Contains(element))
+		}
+	}
+}
+
+func TestConcurrentTreeUpdateKeepsOldSnapshots(t *testing.T) {
+	ct := NewConcurrentTree()
+	ct.Add(1)
+	before := ct.Snapshot()
+
+	ct.Update(func(current *PersistentTree) *PersistentTree {
+		next, _ := current.Add(2)
+		next, _ = next.Remove(1)
+		return next
+	})
+
+	if got, _ := before.PrintTree("inorder"); !slices.Equal(got, []int{1}) {
+		t.Fatalf("old snapshot = %v, want [1]", got)
+	}
+
+	if got, _ := ct.PrintTree("inorder"); !slices.Equal(got, []int{2}) {
+		t.Fatalf("current version = %v, want [2]", got)
+	}
+}
+
+// pairsComplete reports whether every element comes with its pair
+func pairsComplete(data []int) bool {
+	present := make(map[int]bool, len(data))
+
+	for _, element := range data {
+		present[element] = true
+	}
+
+	for _, element := range data {
+		pair := element + pairOffset
+
+		if element >= pairOffset {
+			pair = element - pairOffset
+		}
+
+		if !present[pair] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,263 @@
+package binarysearchtree_test
+
+import (
+	"math/rand/v2"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/seonicklaus/data-structures-go/avltree"
+	"github.com/seonicklaus/data-structures-go/binarysearchtree"
+	"github.com/seonicklaus/data-structures-go/bplustree"
+	"github.com/seonicklaus/data-structures-go/splaytree"
+	"github.com/seonicklaus/data-structures-go/treap"
+)
+
+// implementations builds an empty instance of every OrderedSet
+var implementations = map[string]func(t *testing.T) binarysearchtree.OrderedSet{
+	"BinarySearchTree": func(t *testing.T) binarysearchtree.OrderedSet {
+		return binarysearchtree.NewTree()
+	},
+	"ConcurrentTree": func(t *testing.T) binarysearchtree.OrderedSet {
+		return binarysearchtree.NewConcurrentTree()
+	},
+	"AVLTree": func(t *testing.T) binarysearchtree.OrderedSet {
+		return avltree.NewTree()
+	},
+	"Treap": func(t *testing.T) binarysearchtree.OrderedSet {
+		return treap.NewTree()
+	},
+	"SplayTree": func(t *testing.T) binarysearchtree.OrderedSet {
+		return splaytree.NewTree()
+	},
+	"BPlusTree": func(t *testing.T) binarysearchtree.OrderedSet {
+		tree, err := bplustree.Open(filepath.Join(t.TempDir(), "tree.db"), 128)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() {
+			if err := tree.Err(); err != nil {
+				t.Error(err)
+			}
+
+			tree.Close()
+		})
+
+		return tree
+	},
+}
+
+// orders are the PrintTree orders every OrderedSet accepts
+var orders = []string{"preorder", "inorder", "postorder", "levelorder"}
+
+type operation struct {
+	name    string
+	element int
+	want    bool
+	// inorder is the expected content after the operation
+	inorder []int
+}
+
+var conformanceTable = []struct {
+	name       string
+	operations []operation
+}{
+	{
+		name: "add into empty",
+		operations: []operation{
+			{name: "Add", element: 5, want: true, inorder: []int{5}},
+		},
+	},
+	{
+		name: "duplicate add is rejected",
+		operations: []operation{
+			{name: "Add", element: 5, want: true, inorder: []int{5}},
+			{name: "Add", element: 5, want: false, inorder: []int{5}},
+		},
+	},
+	{
+		name: "remove missing element",
+		operations: []operation{
+			{name: "Remove", element: 5, want: false, inorder: nil},
+			{name: "Add", element: 1, want: true, inorder: []int{1}},
+			{name: "Remove", element: 2, want: false, inorder: []int{1}},
+		},
+	},
+	{
+		name: "remove leaf, one child and two children",
+		operations: []operation{
+			{name: "Add", element: 50, want: true, inorder: []int{50}},
+			{name: "Add", element: 30, want: true, inorder: []int{30, 50}},
+			{name: "Add", element: 70, want: true, inorder: []int{30, 50, 70}},
+			{name: "Add", element: 20, want: true, inorder: []int{20, 30, 50, 70}},
+			{name: "Add", element: 60, want: true, inorder: []int{20, 30, 50, 60, 70}},
+			{name: "Add", element: 80, want: true, inorder: []int{20, 30, 50, 60, 70, 80}},
+			{name: "Remove", element: 20, want: true, inorder: []int{30, 50, 60, 70, 80}},
+			{name: "Remove", element: 30, want: true, inorder: []int{50, 60, 70, 80}},
+			{name: "Remove", element: 70, want: true, inorder: []int{50, 60, 80}},
+			{name: "Remove", element: 50, want: true, inorder: []int{60, 80}},
+			{name: "Contains", element: 50, want: false, inorder: []int{60, 80}},
+			{name: "Contains", element: 80, want: true, inorder: []int{60, 80}},
+		},
+	},
+	{
+		name: "negative and extreme elements",
+		operations: []operation{
+			{name: "Add", element: -1, want: true, inorder: []int{-1}},
+			{name: "Add", element: 1 << 62, want: true, inorder: []int{-1, 1 << 62}},
+			{name: "Add", element: -1 << 62, want: true, inorder: []int{-1 << 62, -1, 1 << 62}},
+			{name: "Contains", element: -1 << 62, want: true, inorder: []int{-1 << 62, -1, 1 << 62}},
+			{name: "Remove", element: -1, want: true, inorder: []int{-1 << 62, 1 << 62}},
+		},
+	},
+	{
+		name: "empty again after removing everything",
+		operations: []operation{
+			{name: "Add", element: 2, want: true, inorder: []int{2}},
+			{name: "Add", element: 1, want: true, inorder: []int{1, 2}},
+			{name: "Remove", element: 2, want: true, inorder: []int{1}},
+			{name: "Remove", element: 1, want: true, inorder: nil},
+			{name: "Contains", element: 1, want: false, inorder: nil},
+		},
+	},
+}
+
+func TestOrderedSetConformance(t *testing.T) {
+	for name, build := range implementations {
+		t.Run(name, func(t *testing.T) {
+			for _, test := range conformanceTable {
+				t.Run(test.name, func(t *testing.T) {
+					set := build(t)
+					checkSet(t, set, nil)
+
+					for _, op := range test.operations {
+						if got := apply(set, op.name, op.element); got != op.want {
+							t.Fatalf("%s(%d) = %v, want %v", op.name, op.element, got, op.want)
+						}
+
+						checkSet(t, set, op.inorder)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestOrderedSetConformanceRandom(t *testing.T) {
+	for name, build := range implementations {
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(1, 2))
+			set := build(t)
+			reference := map[int]bool{}
+
+			for i := 0; i < 2000; i++ {
+				element := r.IntN(300)
+				opName := []string{"Add", "Add", "Remove", "Contains"}[r.IntN(4)]
+
+				want := reference[element]
+
+				switch opName {
+				case "Add":
+					want = !reference[element]
+					reference[element] = true
+				case "Remove":
+					delete(reference, element)
+				}
+
+				if got := apply(set, opName, element); got != want {
+					t.Fatalf("step %d: %s(%d) = %v, want %v", i, opName, element, got, want)
+				}
+
+				if i%100 == 0 {
+					checkSet(t, set, sortedKeys(reference))
+				}
+			}
+
+			checkSet(t, set, sortedKeys(reference))
+		})
+	}
+}
+
+func TestOrderedSetRejectsInvalidOrder(t *testing.T) {
+	for name, build := range implementations {
+		t.Run(name, func(t *testing.T) {
+			set := build(t)
+			set.Add(1)
+
+			if _, err := set.PrintTree("sideways"); err == nil {
+				t.Fatal(`PrintTree("sideways") returned no error`)
+			}
+		})
+	}
+}
+
+func apply(set binarysearchtree.OrderedSet, name string, element int) bool {
+	switch name {
+	case "Add":
+		return set.Add(element)
+	case "Remove":
+		return set.Remove(element)
+	default:
+		return set.Contains(element)
+	}
+}
+
+// checkSet verifies Size, IsEmpty, GetHeight and every PrintTree order against the sorted content
+func checkSet(t *testing.T, set binarysearchtree.OrderedSet, inorder []int) {
+	t.Helper()
+
+	if set.Size() != uint(len(inorder)) {
+		t.Fatalf("Size() = %d, want %d", set.Size(), len(inorder))
+	}
+
+	if set.IsEmpty() != (len(inorder) == 0) {
+		t.Fatalf("IsEmpty() = %v with %d elements", set.IsEmpty(), len(inorder))
+	}
+
+	// An empty set has height 0, otherwise every level holds at least one element
+	if height := set.GetHeight(); (len(inorder) == 0 && height != 0) || (len(inorder) > 0 && (height < 1 || height > len(inorder))) {
+		t.Fatalf("GetHeight() = %d with %d elements", height, len(inorder))
+	}
+
+	for _, order := range orders {
+		data, err := set.PrintTree(order)
+		if err != nil {
+			t.Fatalf("PrintTree(%q): %v", order, err)
+		}
+
+		if order == "inorder" {
+			if !slices.Equal(data, inorder) {
+				t.Fatalf("PrintTree(%q) = %v, want %v", order, data, inorder)
+			}
+
+			continue
+		}
+
+		// The other orders depend on the shape, which differs between implementations
+		sorted := slices.Clone(data)
+		slices.Sort(sorted)
+
+		if !slices.Equal(sorted, inorder) {
+			t.Fatalf("PrintTree(%q) = %v, not a permutation of %v", order, data, inorder)
+		}
+	}
+
+	for _, element := range inorder {
+		if !set.Contains(element) {
+			t.Fatalf("Contains(%d) = false", element)
+		}
+	}
+}
+
+func sortedKeys(set map[int]bool) []int {
+	var keys []int
+
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
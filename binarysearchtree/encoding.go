@@ -0,0 +1,273 @@
+package binarysearchtree
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// The binary encoding is a header followed by the nodes in preorder, each one either
+// a null marker or a node marker with its element as a varint, and its count as an
+// uvarint in multiset trees. Null markers let the exact shape be rebuilt on decode
+const (
+	encodingVersion = 1
+	nullMarker      = 0
+	nodeMarker      = 1
+	multisetFlag    = 1
+)
+
+var encodingMagic = []byte("BST")
+
+// Encoder writes trees to a stream without building the whole encoding in memory
+type Encoder struct {
+	w *bufio.Writer
+}
+
+// Decoder reads trees written by an Encoder from a stream
+type Decoder struct {
+	r *bufio.Reader
+}
+
+// jsonTree represents the JSON encoding, preorder holds elements and nulls for missing
+// children, counts holds the occurrences of each non-null element for multiset trees
+type jsonTree struct {
+	Multiset bool   `json:"multiset"`
+	Preorder []*int `json:"preorder"`
+	Counts   []uint `json:"counts,omitempty"`
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// Encode writes the tree in preorder with null markers, iteratively so degenerate trees do not grow the call stack, O(n)
+func (e *Encoder) Encode(bst *BinarySearchTree) error {
+	bst.guard()
+
+	flags := byte(0)
+
+	if bst.multiset {
+		flags |= multisetFlag
+	}
+
+	e.w.Write(encodingMagic)
+	e.w.WriteByte(encodingVersion)
+	e.w.WriteByte(flags)
+
+	var buf [binary.MaxVarintLen64]byte
+	stack := stack{}
+	stack.push(bst.root)
+
+	for !stack.isEmpty() {
+		n := stack.pop()
+
+		if n == nil {
+			e.w.WriteByte(nullMarker)
+			continue
+		}
+
+		e.w.WriteByte(nodeMarker)
+		e.w.Write(buf[:binary.PutVarint(buf[:], int64(n.data))])
+
+		if bst.multiset {
+			e.w.Write(buf[:binary.PutUvarint(buf[:], uint64(n.count))])
+		}
+
+		stack.push(n.right)
+		stack.push(n.left)
+	}
+
+	return e.w.Flush()
+}
+
+// Decode replaces the contents of bst with the next tree in the stream, O(n)
+func (d *Decoder) Decode(bst *BinarySearchTree) error {
+	bst.guard()
+
+	header := make([]byte, len(encodingMagic)+2)
+
+	if _, err := io.ReadFull(d.r, header); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(header[:len(encodingMagic)], encodingMagic) {
+		return errors.New("invalid tree encoding")
+	}
+
+	if header[len(encodingMagic)] != encodingVersion {
+		return errors.New("unsupported tree encoding version")
+	}
+
+	decoded := &BinarySearchTree{multiset: header[len(encodingMagic)+1]&multisetFlag != 0}
+
+	// Every slot is a child pointer waiting for the next node in preorder
+	slots := []**node{&decoded.root}
+
+	for len(slots) > 0 {
+		slot := slots[len(slots)-1]
+		slots = slots[:len(slots)-1]
+
+		marker, err := d.r.ReadByte()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+
+		if marker == nullMarker {
+			continue
+		}
+
+		if marker != nodeMarker {
+			return errors.New("invalid tree encoding")
+		}
+
+		data, err := binary.ReadVarint(d.r)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+
+		n := &node{data: int(data), count: 1}
+
+		if decoded.multiset {
+			count, err := binary.ReadUvarint(d.r)
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+
+			n.count = uint(count)
+		}
+
+		*slot = n
+		decoded.nodeCount += n.count
+		slots = append(slots, &n.right, &n.left)
+	}
+
+	if !decoded.IsValid() {
+		return errors.New("decoded tree is invalid")
+	}
+
+	decoded.modCount = bst.modCount + 1
+	*bst = *decoded
+
+	return nil
+}
+
+// MarshalBinary encodes the tree preserving its shape
+func (bst *BinarySearchTree) MarshalBinary() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	if err := NewEncoder(&buf).Encode(bst); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the contents of the tree with a tree encoded by MarshalBinary
+func (bst *BinarySearchTree) UnmarshalBinary(data []byte) error {
+	return NewDecoder(bytes.NewReader(data)).Decode(bst)
+}
+
+// MarshalJSON encodes the tree in preorder with nulls for missing children, preserving its shape
+func (bst *BinarySearchTree) MarshalJSON() ([]byte, error) {
+	bst.guard()
+
+	encoded := jsonTree{Multiset: bst.multiset, Preorder: []*int{}}
+	stack := stack{}
+	stack.push(bst.root)
+
+	for !stack.isEmpty() {
+		n := stack.pop()
+
+		if n == nil {
+			encoded.Preorder = append(encoded.Preorder, nil)
+			continue
+		}
+
+		data := n.data
+		encoded.Preorder = append(encoded.Preorder, &data)
+
+		if bst.multiset {
+			encoded.Counts = append(encoded.Counts, n.count)
+		}
+
+		stack.push(n.right)
+		stack.push(n.left)
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON replaces the contents of the tree with a tree encoded by MarshalJSON
+func (bst *BinarySearchTree) UnmarshalJSON(data []byte) error {
+	bst.guard()
+
+	var encoded jsonTree
+
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	decoded := &BinarySearchTree{multiset: encoded.Multiset}
+	slots := []**node{&decoded.root}
+	index := 0
+	nodeIndex := 0
+
+	for len(slots) > 0 {
+		if index >= len(encoded.Preorder) {
+			return errors.New("invalid tree encoding")
+		}
+
+		slot := slots[len(slots)-1]
+		slots = slots[:len(slots)-1]
+		value := encoded.Preorder[index]
+		index++
+
+		if value == nil {
+			continue
+		}
+
+		n := &node{data: *value, count: 1}
+
+		if decoded.multiset {
+			if nodeIndex >= len(encoded.Counts) {
+				return errors.New("invalid tree encoding")
+			}
+
+			n.count = encoded.Counts[nodeIndex]
+		}
+
+		nodeIndex++
+		*slot = n
+		decoded.nodeCount += n.count
+		slots = append(slots, &n.right, &n.left)
+	}
+
+	if index != len(encoded.Preorder) {
+		return errors.New("invalid tree encoding")
+	}
+
+	if !decoded.IsValid() {
+		return errors.New("decoded tree is invalid")
+	}
+
+	decoded.modCount = bst.modCount + 1
+	*bst = *decoded
+
+	return nil
+}
+
+// unexpectedEOF reports a stream that ends in the middle of a tree as io.ErrUnexpectedEOF
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
@@ -0,0 +1,202 @@
+package binarysearchtree
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"testing"
+)
+
+// encodingTrees returns trees whose shape a round trip must preserve
+func encodingTrees() map[string]*BinarySearchTree {
+	empty := NewTree()
+
+	degenerate := NewTree()
+	for i := -100; i < 100; i++ {
+		degenerate.Add(i)
+	}
+
+	zigzag := NewTree()
+	for _, v := range []int{0, math.MaxInt, math.MinInt, 1000, -1000, 500, -500} {
+		zigzag.Add(v)
+	}
+
+	multiset := NewMultiset()
+	for _, v := range []int{50, 30, 70, 30, 30, 80, 70, 20, 1 << 40} {
+		multiset.Add(v)
+	}
+
+	return map[string]*BinarySearchTree{
+		"empty":      empty,
+		"degenerate": degenerate,
+		"zigzag":     zigzag,
+		"multiset":   multiset,
+		"balanced":   FromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}),
+	}
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	codecs := map[string]func(*BinarySearchTree) (*BinarySearchTree, error){
+		"binary": func(bst *BinarySearchTree) (*BinarySearchTree, error) {
+			data, err := bst.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+
+			decoded := NewTree()
+			return decoded, decoded.UnmarshalBinary(data)
+		},
+		"json": func(bst *BinarySearchTree) (*BinarySearchTree, error) {
+			data, err := bst.MarshalJSON()
+			if err != nil {
+				return nil, err
+			}
+
+			decoded := NewTree()
+			return decoded, decoded.UnmarshalJSON(data)
+		},
+		"stream": func(bst *BinarySearchTree) (*BinarySearchTree, error) {
+			buf := bytes.Buffer{}
+
+			if err := NewEncoder(&buf).Encode(bst); err != nil {
+				return nil, err
+			}
+
+			decoded := NewTree()
+			return decoded, NewDecoder(&buf).Decode(decoded)
+		},
+	}
+
+	for codec, roundTrip := range codecs {
+		for name, bst := range encodingTrees() {
+			t.Run(codec+"/"+name, func(t *testing.T) {
+				decoded, err := roundTrip(bst)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !decoded.IsStructurallyIdentical(bst) {
+					t.Error("decoded tree has a different shape")
+				}
+
+				if decoded.Size() != bst.Size() || decoded.multiset != bst.multiset {
+					t.Errorf("decoded Size() = %d multiset %t, want %d and %t", decoded.Size(), decoded.multiset, bst.Size(), bst.multiset)
+				}
+			})
+		}
+	}
+}
+
+func TestDecoderReadsSeveralTrees(t *testing.T) {
+	trees := encodingTrees()
+	names := []string{"degenerate", "empty", "multiset", "zigzag"}
+	buf := bytes.Buffer{}
+	e := NewEncoder(&buf)
+
+	for _, name := range names {
+		if err := e.Encode(trees[name]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewDecoder(&buf)
+
+	for _, name := range names {
+		decoded := NewTree()
+
+		if err := d.Decode(decoded); err != nil {
+			t.Fatalf("Decode %s: %v", name, err)
+		}
+
+		if !decoded.IsStructurallyIdentical(trees[name]) {
+			t.Errorf("Decode %s returned a different tree", name)
+		}
+	}
+
+	if err := d.Decode(NewTree()); err != io.EOF {
+		t.Errorf("Decode at end of stream = %v, want io.EOF", err)
+	}
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	data, _ := encodingTrees()["multiset"].MarshalBinary()
+
+	for cut := 1; cut < len(data); cut++ {
+		if err := NewTree().UnmarshalBinary(data[:cut]); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("decoding %d of %d bytes = %v, want io.ErrUnexpectedEOF", cut, len(data), err)
+		}
+	}
+}
+
+func TestDecodeRejectsBadHeader(t *testing.T) {
+	data, _ := FromSlice([]int{1, 2, 3}).MarshalBinary()
+
+	badMagic := slices.Clone(data)
+	badMagic[0] = 'X'
+
+	badVersion := slices.Clone(data)
+	badVersion[len(encodingMagic)] = encodingVersion + 1
+
+	for name, encoded := range map[string][]byte{"magic": badMagic, "version": badVersion} {
+		if err := NewTree().UnmarshalBinary(encoded); err == nil {
+			t.Errorf("bad %s accepted", name)
+		}
+	}
+}
+
+// An invalid encoding returns an error and leaves the target as it was
+func TestDecodeRejectsInvalidTree(t *testing.T) {
+	outOfOrder := &BinarySearchTree{
+		root:      &node{data: 5, count: 1, left: &node{data: 9, count: 1}},
+		nodeCount: 2,
+	}
+
+	zeroCount := &BinarySearchTree{
+		root:      &node{data: 5, count: 0},
+		multiset:  true,
+		nodeCount: 0,
+	}
+
+	encodings := map[string]func() ([]byte, func(*BinarySearchTree, []byte) error){
+		"binary order": func() ([]byte, func(*BinarySearchTree, []byte) error) {
+			data, _ := outOfOrder.MarshalBinary()
+			return data, (*BinarySearchTree).UnmarshalBinary
+		},
+		"binary count": func() ([]byte, func(*BinarySearchTree, []byte) error) {
+			data, _ := zeroCount.MarshalBinary()
+			return data, (*BinarySearchTree).UnmarshalBinary
+		},
+		"json order": func() ([]byte, func(*BinarySearchTree, []byte) error) {
+			data, _ := outOfOrder.MarshalJSON()
+			return data, (*BinarySearchTree).UnmarshalJSON
+		},
+		"json missing counts": func() ([]byte, func(*BinarySearchTree, []byte) error) {
+			return []byte(`{"multiset":true,"preorder":[5,null,null]}`), (*BinarySearchTree).UnmarshalJSON
+		},
+		"json trailing elements": func() ([]byte, func(*BinarySearchTree, []byte) error) {
+			return []byte(`{"multiset":false,"preorder":[5,null,null,7]}`), (*BinarySearchTree).UnmarshalJSON
+		},
+		"json truncated preorder": func() ([]byte, func(*BinarySearchTree, []byte) error) {
+			return []byte(`{"multiset":false,"preorder":[5,null]}`), (*BinarySearchTree).UnmarshalJSON
+		},
+	}
+
+	for name, encoding := range encodings {
+		t.Run(name, func(t *testing.T) {
+			data, decode := encoding()
+			target := FromSlice([]int{1, 2, 3})
+			original := FromSlice([]int{1, 2, 3})
+			modCount := target.modCount
+
+			if err := decode(target, data); err == nil {
+				t.Fatal("invalid encoding accepted")
+			}
+
+			if !target.IsStructurallyIdentical(original) || target.Size() != 3 || target.modCount != modCount {
+				t.Error("rejected decode changed the target tree")
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package binarysearchtree
+
+import (
+	"iter"
+	"sync/atomic"
+)
+
+type cursorState int
+
+const (
+	beforeFirst cursorState = iota
+	onNode
+	afterLast
+)
+
+// Cursor represents a lazy, bidirectional position in the inorder sequence of a
+// Binary Search Tree, it holds the path from the root to the current node and
+// which occurrence of the node's element it is on
+type Cursor struct {
+	tree             *BinarySearchTree
+	path             stack
+	occurrence       uint
+	state            cursorState
+	expectedModCount uint64
+	err              error
+}
+
+// Cursor returns a cursor positioned before the smallest element
+func (bst *BinarySearchTree) Cursor() *Cursor {
+	return &Cursor{tree: bst, expectedModCount: bst.modCount}
+}
+
+// Next moves the cursor to the next element in ascending order, O(1) amortized
+func (c *Cursor) Next() bool {
+	if !c.valid() {
+		return false
+	}
+
+	switch c.state {
+	case beforeFirst:
+		c.path = stack{}
+		c.pushLeft(c.tree.root)
+	case onNode:
+		n := c.path.peek()
+
+		if c.occurrence+1 < n.count {
+			c.occurrence++
+			return true
+		}
+
+		if n.right != nil {
+			c.pushLeft(n.right)
+		} else {
+			child := c.path.pop()
+
+			for !c.path.isEmpty() && c.path.peek().right == child {
+				child = c.path.pop()
+			}
+		}
+	case afterLast:
+		return false
+	}
+
+	if !c.settle(afterLast) {
+		return false
+	}
+
+	c.occurrence = 0
+	return true
+}
+
+// Prev moves the cursor to the previous element in ascending order, O(1) amortized
+func (c *Cursor) Prev() bool {
+	if !c.valid() {
+		return false
+	}
+
+	switch c.state {
+	case afterLast:
+		c.path = stack{}
+		c.pushRight(c.tree.root)
+	case onNode:
+		n := c.path.peek()
+
+		if c.occurrence > 0 {
+			c.occurrence--
+			return true
+		}
+
+		if n.left != nil {
+			c.pushRight(n.left)
+		} else {
+			child := c.path.pop()
+
+			for !c.path.isEmpty() && c.path.peek().left == child {
+				child = c.path.pop()
+			}
+		}
+	case beforeFirst:
+		return false
+	}
+
+	if !c.settle(beforeFirst) {
+		return false
+	}
+
+	c.occurrence = c.path.peek().count - 1
+	return true
+}
+
+// Seek moves the cursor to the smallest element greater than or equal to element, O(h)
+func (c *Cursor) Seek(element int) bool {
+	if !c.valid() {
+		return false
+	}
+
+	c.path = stack{}
+	ceilingDepth := 0
+
+	for n := c.tree.root; n != nil; {
+		c.path.push(n)
+		cmp := compareTo(element, n.data)
+
+		if cmp == 0 {
+			ceilingDepth = c.path.size
+			break
+		}
+
+		if cmp < 0 {
+			ceilingDepth = c.path.size
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	c.path.items = c.path.items[:ceilingDepth]
+	c.path.size = ceilingDepth
+	c.occurrence = 0
+
+	return c.settle(afterLast)
+}
+
+// Value returns the element under the cursor, only meaningful after Next, Prev or Seek returned true
+func (c *Cursor) Value() int {
+	if c.state != onNode {
+		return 0
+	}
+
+	return c.path.peek().data
+}
+
+// Err returns the error that stopped the cursor, if any
+func (c *Cursor) Err() error {
+	return c.err
+}
+
+// valid checks that the tree was not modified since the cursor was created, and is not
+// threaded by a Morris walk
+func (c *Cursor) valid() bool {
+	if c.err != nil {
+		return false
+	}
+
+	if c.expectedModCount != c.tree.modCount || atomic.LoadUint32(&c.tree.threading) != 0 {
+		c.err = ErrModified
+		c.path = stack{}
+		return false
+	}
+
+	return true
+}
+
+// settle marks the cursor as on a node, or as past the end when the path ran out
+func (c *Cursor) settle(end cursorState) bool {
+	if c.path.isEmpty() {
+		c.state = end
+		return false
+	}
+
+	c.state = onNode
+	return true
+}
+
+func (c *Cursor) pushLeft(n *node) {
+	for ; n != nil; n = n.left {
+		c.path.push(n)
+	}
+}
+
+func (c *Cursor) pushRight(n *node) {
+	for ; n != nil; n = n.right {
+		c.path.push(n)
+	}
+}
+
+// Ascending returns a lazy iterator over the elements in sorted order, it panics with ErrModified if the tree is modified while iterating
+func (bst *BinarySearchTree) Ascending() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		c := bst.Cursor()
+
+		for c.Next() {
+			if !yield(c.Value()) {
+				return
+			}
+		}
+
+		if c.Err() != nil {
+			panic(c.Err())
+		}
+	}
+}
+
+// Descending returns a lazy iterator over the elements in reverse sorted order, it panics with ErrModified if the tree is modified while iterating
+func (bst *BinarySearchTree) Descending() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		c := bst.Cursor()
+		c.state = afterLast
+
+		for c.Prev() {
+			if !yield(c.Value()) {
+				return
+			}
+		}
+
+		if c.Err() != nil {
+			panic(c.Err())
+		}
+	}
+}
+
+// Preorder returns a lazy preorder iterator, it panics with ErrModified if the tree is modified while iterating
+func (bst *BinarySearchTree) Preorder() iter.Seq[int] {
+	return bst.walkSeq(PreOrder)
+}
+
+// Inorder returns a lazy inorder iterator, it panics with ErrModified if the tree is modified while iterating
+func (bst *BinarySearchTree) Inorder() iter.Seq[int] {
+	return bst.walkSeq(InOrder)
+}
+
+// Postorder returns a lazy postorder iterator, it panics with ErrModified if the tree is modified while iterating
+func (bst *BinarySearchTree) Postorder() iter.Seq[int] {
+	return bst.walkSeq(PostOrder)
+}
+
+// Levelorder returns a lazy levelorder iterator, it panics with ErrModified if the tree is modified while iterating
+func (bst *BinarySearchTree) Levelorder() iter.Seq[int] {
+	return bst.walkSeq(LevelOrder)
+}
+
+// walkSeq adapts Walk to an iterator, an iterator cannot return an error so the one
+// returned by Walk is raised as a panic
+func (bst *BinarySearchTree) walkSeq(order Order) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		err := bst.Walk(order, func(value int, depth int) bool {
+			return yield(value)
+		})
+
+		if err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package binarysearchtree
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestCursorDetectsModificationWithUnchangedSize(t *testing.T) {
+	bst := FromSlice([]int{3, 4, 5, 8})
+	c := bst.Cursor()
+
+	if !c.Seek(3) || c.Value() != 3 {
+		t.Fatalf("Seek(3) = %d, want 3", c.Value())
+	}
+
+	bst.Add(100)
+	bst.Remove(3)
+
+	if c.Next() {
+		t.Fatalf("Next() = true after Add and Remove, got %d", c.Value())
+	}
+
+	if c.Err() == nil {
+		t.Fatal("Err() = nil after Add and Remove")
+	}
+}
+
+func TestCursorDetectsRebalance(t *testing.T) {
+	bst := NewTree()
+
+	for i := 1; i <= 7; i++ {
+		bst.Add(i)
+	}
+
+	c := bst.Cursor()
+	c.Next()
+	bst.Rebalance()
+
+	if c.Next() || c.Err() == nil {
+		t.Fatal("cursor kept going after Rebalance")
+	}
+}
+
+func TestIteratorsMatchWalk(t *testing.T) {
+	bst := NewMultiset()
+
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80, 30, 70} {
+		bst.Add(v)
+	}
+
+	iterators := map[Order]iter.Seq[int]{
+		PreOrder:   bst.Preorder(),
+		InOrder:    bst.Inorder(),
+		PostOrder:  bst.Postorder(),
+		LevelOrder: bst.Levelorder(),
+	}
+
+	for order, seq := range iterators {
+		want, err := bst.Collect(order)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := slices.Collect(seq); !slices.Equal(got, want) {
+			t.Errorf("%v iterator = %v, want %v", order, got, want)
+		}
+	}
+}
+
+func TestIteratorsPanicWithErrModified(t *testing.T) {
+	iterators := map[string]func(bst *BinarySearchTree) iter.Seq[int]{
+		"Ascending":  (*BinarySearchTree).Ascending,
+		"Descending": (*BinarySearchTree).Descending,
+		"Preorder":   (*BinarySearchTree).Preorder,
+		"Inorder":    (*BinarySearchTree).Inorder,
+		"Postorder":  (*BinarySearchTree).Postorder,
+		"Levelorder": (*BinarySearchTree).Levelorder,
+	}
+
+	for name, seq := range iterators {
+		t.Run(name, func(t *testing.T) {
+			bst := FromSlice([]int{1, 2, 3})
+
+			defer func() {
+				if r := recover(); r != ErrModified {
+					t.Fatalf("recovered %v, want ErrModified", r)
+				}
+			}()
+
+			for range seq(bst) {
+				bst.Add(10)
+				bst.Remove(10)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package binarysearchtree
+
+import (
+	"sync/atomic"
+)
+
+// Morris traversals use O(1) extra space: instead of a stack, the right pointer of the
+// inorder predecessor of a node is temporarily threaded back to the node so the walk
+// can climb back up, and every thread is removed on the way back so the tree is
+// restored exactly. Once visit returns false the walk continues without visiting
+// until every thread is removed. A threaded tree has cycles, so threading is set for
+// the whole walk and every method that follows the nodes refuses to run meanwhile.
+// Following a thread overshoots the depth by the length of the path from the node
+// down to its predecessor, which is counted while searching for the predecessor
+
+// walkMorris visits the nodes in preorder or inorder, O(n) time, O(1) extra space, it
+// returns ErrModified when another Morris walk holds the tree or visit uses the tree
+func (bst *BinarySearchTree) walkMorris(preorder bool, visit func(n *node, depth int) bool) (err error) {
+	if !atomic.CompareAndSwapUint32(&bst.threading, 0, 1) {
+		return ErrModified
+	}
+
+	defer atomic.StoreUint32(&bst.threading, 0)
+
+	// guard raised ErrModified inside visit, the threads are removed by the time it gets here
+	defer func() {
+		if r := recover(); r != nil {
+			if r != ErrModified {
+				panic(r)
+			}
+
+			err = ErrModified
+		}
+	}()
+
+	bst.morris(preorder, visit)
+
+	return nil
+}
+
+// guard panics with ErrModified while a Morris walk has threaded the tree
+func (bst *BinarySearchTree) guard() {
+	if atomic.LoadUint32(&bst.threading) != 0 {
+		panic(ErrModified)
+	}
+}
+
+// morris visits the nodes in preorder or inorder. If visit panics the walk carries on
+// from the same node without visiting, which removes every thread before the panic
+// goes on, a node whose thread was already removed is simply threaded and walked again
+func (bst *BinarySearchTree) morris(preorder bool, visit func(n *node, depth int) bool) {
+	current := bst.root
+	depth := 0
+	visiting := true
+
+	walk := func() {
+		for current != nil {
+			if current.left == nil {
+				visiting = visiting && visit(current, depth)
+				current = current.right
+				depth++
+				continue
+			}
+
+			predecessor := current.left
+			steps := 0
+
+			for predecessor.right != nil && predecessor.right != current {
+				predecessor = predecessor.right
+				steps++
+			}
+
+			if predecessor.right == nil {
+				if preorder {
+					visiting = visiting && visit(current, depth)
+				}
+
+				predecessor.right = current
+				current = current.left
+				depth++
+			} else {
+				predecessor.right = nil
+				depth -= steps + 2
+
+				if !preorder {
+					visiting = visiting && visit(current, depth)
+				}
+
+				current = current.right
+				depth++
+			}
+		}
+	}
+
+	defer func() {
+		if current != nil {
+			visiting = false
+			walk()
+		}
+	}()
+
+	walk()
+}
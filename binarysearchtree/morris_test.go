@@ -0,0 +1,190 @@
+package binarysearchtree
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestMorrisRestoresTreeWhenVisitPanics(t *testing.T) {
+	for _, order := range []Order{MorrisInOrder, MorrisPreOrder} {
+		for stop := 1; stop <= 7; stop++ {
+			bst := FromSlice([]int{1, 2, 3, 4, 5, 6, 7})
+			want, _ := bst.Collect(PreOrder)
+
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatalf("%v: visit did not panic", order)
+					}
+				}()
+
+				visited := 0
+
+				bst.Walk(order, func(value int, depth int) bool {
+					if visited++; visited == stop {
+						panic("stop")
+					}
+
+					return true
+				})
+			}()
+
+			if !bst.IsValid() {
+				t.Fatalf("%v: tree invalid after a panic at visit %d", order, stop)
+			}
+
+			if got, _ := bst.Collect(PreOrder); !slices.Equal(got, want) {
+				t.Fatalf("%v: preorder after a panic at visit %d = %v, want %v", order, stop, got, want)
+			}
+		}
+	}
+}
+
+func TestMorrisOrdersOnPersistentTreeLeaveNodesUntouched(t *testing.T) {
+	pt := NewPersistentTree()
+
+	for _, v := range []int{4, 2, 6, 1, 3, 5, 7} {
+		pt, _ = pt.Add(v)
+	}
+
+	// A Morris walk would thread 3 back to 4 while it runs, record the right links to compare
+	var links func(n *node, into []*node) []*node
+	links = func(n *node, into []*node) []*node {
+		if n == nil {
+			return into
+		}
+
+		into = append(into, n.right)
+		into = links(n.left, into)
+		return links(n.right, into)
+	}
+
+	before := links(pt.root, nil)
+	stopped := false
+
+	pt.view().Walk(MorrisInOrder, func(value int, depth int) bool {
+		if value == 3 && !slices.Equal(links(pt.root, nil), before) {
+			stopped = true
+		}
+
+		return true
+	})
+
+	if stopped {
+		t.Fatal("Morris walk threaded the nodes of a persistent version")
+	}
+
+	for _, order := range []string{"morris-inorder", "morris-preorder"} {
+		got, err := pt.PrintTree(order)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, _ := pt.PrintTree(order[len("morris-"):])
+
+		if !slices.Equal(got, want) {
+			t.Errorf("PrintTree(%q) = %v, want %v", order, got, want)
+		}
+	}
+}
+
+// While the nodes are threaded the tree refuses every use from visit, the walk then stops
+// with ErrModified like the stack orders do, and the tree is restored
+func TestMorrisStopsWhenVisitUsesTree(t *testing.T) {
+	uses := map[string]func(bst *BinarySearchTree, value int){
+		"Contains": func(bst *BinarySearchTree, value int) { bst.Contains(25) },
+		"Add":      func(bst *BinarySearchTree, value int) { bst.Add(value + 1) },
+		"Remove":   func(bst *BinarySearchTree, value int) { bst.Remove(value) },
+		"GetHeight": func(bst *BinarySearchTree, value int) {
+			bst.GetHeight()
+		},
+		"Walk": func(bst *BinarySearchTree, value int) {
+			if err := bst.Walk(InOrder, func(int, int) bool { return true }); err != ErrModified {
+				panic("nested Walk did not return ErrModified")
+			}
+
+			bst.Add(value + 1)
+		},
+	}
+
+	for name, use := range uses {
+		for _, order := range []Order{MorrisInOrder, MorrisPreOrder} {
+			bst, err := FromSorted([]int{10, 20, 30, 40, 50})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want, _ := bst.Collect(PreOrder)
+
+			err = bst.Walk(order, func(value int, depth int) bool {
+				use(bst, value)
+				return true
+			})
+
+			if err != ErrModified {
+				t.Errorf("%s from a %v visit: Walk returned %v, want ErrModified", name, order, err)
+			}
+
+			if got, _ := bst.Collect(PreOrder); !bst.IsValid() || !slices.Equal(got, want) {
+				t.Errorf("%s from a %v visit: tree left as %v, want %v", name, order, got, want)
+			}
+
+			if !bst.Contains(30) || bst.Contains(25) {
+				t.Errorf("%s from a %v visit: tree unusable after the walk", name, order)
+			}
+		}
+	}
+}
+
+func TestMorrisCursorStopsOnThreadedTree(t *testing.T) {
+	bst := FromSlice([]int{2, 1, 3})
+	c := bst.Cursor()
+
+	bst.Walk(MorrisInOrder, func(value int, depth int) bool {
+		if c.Next() {
+			t.Error("cursor moved over a threaded tree")
+		}
+
+		return true
+	})
+
+	if c.Err() != ErrModified {
+		t.Fatalf("Err() = %v, want ErrModified", c.Err())
+	}
+}
+
+// Concurrent Morris walks on one tree either run one after another or return ErrModified,
+// they never thread the same nodes at once, run with -race
+func TestConcurrentMorrisWalks(t *testing.T) {
+	bst := FromSlice([]int{50, 30, 70, 20, 40, 60, 80, 10, 90})
+	want, _ := bst.PrintTree("inorder")
+
+	var wg sync.WaitGroup
+
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 200; i++ {
+				got, err := bst.PrintTree("morris-inorder")
+				if err == ErrModified {
+					continue
+				}
+
+				if err != nil || !slices.Equal(got, want) {
+					t.Errorf("PrintTree(morris-inorder) = %v, %v, want %v", got, err, want)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if !bst.IsValid() {
+		t.Fatal("tree invalid after concurrent Morris walks")
+	}
+}
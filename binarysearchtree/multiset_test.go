@@ -0,0 +1,182 @@
+package binarysearchtree
+
+import (
+	"slices"
+	"testing"
+)
+
+// multisetElements are added in this order, so the shape matches the set built from them
+var multisetElements = []int{50, 30, 70, 30, 20, 40, 60, 80, 70, 70, 20, 50, 65}
+
+func newTestMultiset() *BinarySearchTree {
+	bst := NewMultiset()
+
+	for _, v := range multisetElements {
+		bst.Add(v)
+	}
+
+	return bst
+}
+
+func TestMultisetCountAndSize(t *testing.T) {
+	bst := newTestMultiset()
+	counts := map[int]uint{20: 2, 30: 2, 40: 1, 50: 2, 60: 1, 65: 1, 70: 3, 80: 1}
+
+	if bst.Size() != uint(len(multisetElements)) {
+		t.Errorf("Size() = %d, want %d", bst.Size(), len(multisetElements))
+	}
+
+	for v, count := range counts {
+		if bst.Count(v) != count {
+			t.Errorf("Count(%d) = %d, want %d", v, bst.Count(v), count)
+		}
+	}
+
+	if bst.Count(55) != 0 {
+		t.Errorf("Count(55) = %d, want 0", bst.Count(55))
+	}
+
+	set := NewTree()
+	set.Add(5)
+
+	if set.Add(5) || set.Count(5) != 1 || set.Size() != 1 {
+		t.Error("a set counted a duplicate")
+	}
+}
+
+func TestMultisetRemoveDecrementsThenRemoves(t *testing.T) {
+	bst := newTestMultiset()
+	size := bst.Size()
+
+	for want := uint(2); want > 0; want-- {
+		if !bst.Remove(70) {
+			t.Fatal("Remove(70) = false with occurrences left")
+		}
+
+		size--
+
+		if bst.Count(70) != want || !bst.Contains(70) || bst.Size() != size {
+			t.Fatalf("after Remove(70): Count() = %d, Size() = %d, want %d and %d", bst.Count(70), bst.Size(), want, size)
+		}
+	}
+
+	if !bst.Remove(70) || bst.Contains(70) || bst.Remove(70) {
+		t.Fatal("last occurrence of 70 was not removed with its node")
+	}
+
+	if !bst.IsValid() || bst.Size() != size-1 {
+		t.Errorf("tree is invalid or has Size() %d, want %d", bst.Size(), size-1)
+	}
+}
+
+// Removing a node with two children moves its successor up together with every occurrence
+func TestMultisetRemoveCopiesSuccessorCount(t *testing.T) {
+	bst := NewMultiset()
+
+	for _, v := range []int{50, 30, 80, 60, 60, 60, 90, 70} {
+		bst.Add(v)
+	}
+
+	if !bst.Remove(50) {
+		t.Fatal("Remove(50) = false")
+	}
+
+	if bst.root.data != 60 || bst.root.count != 3 {
+		t.Fatalf("root = %d(x%d), want 60(x3)", bst.root.data, bst.root.count)
+	}
+
+	if !bst.IsValid() || bst.Count(60) != 3 || bst.Size() != 7 {
+		t.Errorf("after Remove(50): valid %t, Count(60) = %d, Size() = %d", bst.IsValid(), bst.Count(60), bst.Size())
+	}
+
+	if got, want := slices.Collect(bst.Ascending()), []int{30, 60, 60, 60, 70, 80, 90}; !slices.Equal(got, want) {
+		t.Errorf("Ascending() = %v, want %v", got, want)
+	}
+}
+
+// Every order emits each occurrence, consecutively, in the node order of the set of the same shape
+func TestMultisetTraversalsEmitEveryOccurrence(t *testing.T) {
+	bst := newTestMultiset()
+	set := NewTree()
+
+	for _, v := range multisetElements {
+		set.Add(v)
+	}
+
+	for order := range orderNames {
+		nodes, err := set.Collect(order)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var want []int
+
+		for _, v := range nodes {
+			for i := uint(0); i < bst.Count(v); i++ {
+				want = append(want, v)
+			}
+		}
+
+		if got, err := bst.Collect(order); err != nil || !slices.Equal(got, want) {
+			t.Errorf("Collect(%s) = %v, %v, want %v", order, got, err, want)
+		}
+
+		if got, err := bst.PrintTree(order.String()); err != nil || !slices.Equal(got, want) {
+			t.Errorf("PrintTree(%s) = %v, %v, want %v", order, got, err, want)
+		}
+	}
+
+	inorder := slices.Sorted(slices.Values(multisetElements))
+	descending := slices.Clone(inorder)
+	slices.Reverse(descending)
+
+	iterators := map[string]struct {
+		got  []int
+		want []int
+	}{
+		"Ascending":  {slices.Collect(bst.Ascending()), inorder},
+		"Descending": {slices.Collect(bst.Descending()), descending},
+		"Inorder":    {slices.Collect(bst.Inorder()), inorder},
+	}
+
+	for name, it := range iterators {
+		if !slices.Equal(it.got, it.want) {
+			t.Errorf("%s() = %v, want %v", name, it.got, it.want)
+		}
+	}
+}
+
+func TestMultisetCursorVisitsEveryOccurrence(t *testing.T) {
+	bst := newTestMultiset()
+	c := bst.Cursor()
+
+	if !c.Seek(70) {
+		t.Fatal("Seek(70) = false")
+	}
+
+	var got []int
+
+	for ok := true; ok; ok = c.Next() {
+		got = append(got, c.Value())
+	}
+
+	if want := []int{70, 70, 70, 80}; !slices.Equal(got, want) {
+		t.Errorf("Next from Seek(70) = %v, want %v", got, want)
+	}
+
+	// Walk back from the end across the occurrences of 70 and 65
+	got = nil
+
+	for i := 0; i < 5 && c.Prev(); i++ {
+		got = append(got, c.Value())
+	}
+
+	if want := []int{80, 70, 70, 70, 65}; !slices.Equal(got, want) {
+		t.Errorf("Prev from the end = %v, want %v", got, want)
+	}
+
+	// Seek between elements lands on the first occurrence of the ceiling
+	if !c.Seek(25) || c.Value() != 30 || !c.Next() || c.Value() != 30 || !c.Next() || c.Value() != 40 {
+		t.Error("Seek(25) did not land on the first of two occurrences of 30")
+	}
+}
@@ -0,0 +1,15 @@
+package binarysearchtree
+
+// OrderedSet represents the public API shared by the ordered tree variants, so callers
+// can pick an implementation per workload without changing their code
+type OrderedSet interface {
+	Size() uint
+	IsEmpty() bool
+	Contains(element int) bool
+	Add(element int) bool
+	Remove(element int) bool
+	GetHeight() int
+	PrintTree(order string) ([]int, error)
+}
+
+var _ OrderedSet = (*BinarySearchTree)(nil)
@@ -0,0 +1,119 @@
+package binarysearchtree
+
+import (
+	"iter"
+)
+
+// PersistentTree represents an immutable version of a Binary Search Tree, Add and
+// Remove copy the path from the root to the changed node and return a new version
+// that shares every untouched subtree with the old one
+type PersistentTree struct {
+	root      *node
+	nodeCount uint
+}
+
+func (pt *PersistentTree) Size() uint {
+	return pt.nodeCount
+}
+
+func (pt *PersistentTree) IsEmpty() bool {
+	return pt.nodeCount == 0
+}
+
+func (pt *PersistentTree) Contains(element int) bool {
+	return pt.view().Contains(element)
+}
+
+func (pt *PersistentTree) GetHeight() int {
+	return pt.view().GetHeight()
+}
+
+// PrintTree accepts the same orders as BinarySearchTree, the Morris orders are walked with
+// a stack since versions share nodes
+func (pt *PersistentTree) PrintTree(order string) ([]int, error) {
+	return pt.view().PrintTree(order)
+}
+
+// Ascending returns a lazy iterator over the elements of this version in sorted order
+func (pt *PersistentTree) Ascending() iter.Seq[int] {
+	return pt.view().Ascending()
+}
+
+// Add returns a new version containing element, and the same version if element is already present, O(h)
+func (pt *PersistentTree) Add(element int) (*PersistentTree, bool) {
+	if pt.Contains(element) {
+		return pt, false
+	}
+
+	return &PersistentTree{root: pt.add(pt.root, element), nodeCount: pt.nodeCount + 1}, true
+}
+
+// Remove returns a new version without element, and the same version if element is absent, O(h)
+func (pt *PersistentTree) Remove(element int) (*PersistentTree, bool) {
+	if !pt.Contains(element) {
+		return pt, false
+	}
+
+	return &PersistentTree{root: pt.remove(pt.root, element), nodeCount: pt.nodeCount - 1}, true
+}
+
+func (pt *PersistentTree) add(n *node, element int) *node {
+
+	if n == nil {
+		return &node{data: element, count: 1}
+	}
+
+	copied := n.clone()
+
+	if compareTo(element, n.data) > 0 {
+		copied.right = pt.add(n.right, element)
+	} else {
+		copied.left = pt.add(n.left, element)
+	}
+
+	return copied
+}
+
+// remove never writes to an existing node, element must be present in the subtree
+func (pt *PersistentTree) remove(n *node, element int) *node {
+
+	cmp := compareTo(element, n.data)
+
+	if cmp == 0 {
+		if n.left == nil {
+			return n.right
+		} else if n.right == nil {
+			return n.left
+		}
+	}
+
+	copied := n.clone()
+
+	if cmp < 0 {
+		copied.left = pt.remove(n.left, element)
+	} else if cmp > 0 {
+		copied.right = pt.remove(n.right, element)
+	} else {
+		smallestRight := pt.view().digLeft(n.right)
+		copied.data = smallestRight.data
+		copied.count = smallestRight.count
+		copied.right = pt.remove(n.right, smallestRight.data)
+	}
+
+	return copied
+}
+
+// view wraps this version in a BinarySearchTree for read-only use, it must never be mutated,
+// and it is marked shared so Morris walks never thread nodes other versions read
+func (pt *PersistentTree) view() *BinarySearchTree {
+	return &BinarySearchTree{root: pt.root, nodeCount: pt.nodeCount, shared: true}
+}
+
+func (n *node) clone() *node {
+	copied := *n
+	return &copied
+}
+
+func NewPersistentTree() *PersistentTree {
+	return &PersistentTree{}
+}
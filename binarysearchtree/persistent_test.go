@@ -0,0 +1,120 @@
+package binarysearchtree
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// nodesOf returns every node reachable from n
+func nodesOf(n *node, nodes map[*node]bool) map[*node]bool {
+	if n != nil {
+		nodes[n] = true
+		nodesOf(n.left, nodes)
+		nodesOf(n.right, nodes)
+	}
+
+	return nodes
+}
+
+// perfectVersion returns the persistent version of the perfect tree over 1..15
+func perfectVersion() *PersistentTree {
+	pt := NewPersistentTree()
+
+	for _, v := range []int{8, 4, 12, 2, 6, 10, 14, 1, 3, 5, 7, 9, 11, 13, 15} {
+		pt, _ = pt.Add(v)
+	}
+
+	return pt
+}
+
+// Every version keeps its elements while later versions are derived from any of them,
+// and each new version creates no more nodes than the path it copies
+func TestPersistentVersionsStayUnchanged(t *testing.T) {
+	r := rand.New(rand.NewPCG(8, 1))
+	versions := []*PersistentTree{NewPersistentTree()}
+	sets := []map[int]bool{{}}
+
+	for i := 0; i < 500; i++ {
+		base := r.IntN(len(versions))
+		v := r.IntN(60)
+		set := maps.Clone(sets[base])
+
+		var next *PersistentTree
+		var changed bool
+
+		if r.IntN(3) == 0 {
+			next, changed = versions[base].Remove(v)
+			delete(set, v)
+		} else {
+			next, changed = versions[base].Add(v)
+			set[v] = true
+		}
+
+		if changed != (len(set) != len(sets[base])) {
+			t.Fatalf("version %d: change of %d reported %t", base, v, changed)
+		}
+
+		if !changed && next != versions[base] {
+			t.Fatalf("version %d: unchanged version is a new tree", base)
+		}
+
+		old := nodesOf(versions[base].root, map[*node]bool{})
+		created := 0
+
+		for n := range nodesOf(next.root, map[*node]bool{}) {
+			if !old[n] {
+				created++
+			}
+		}
+
+		if created > versions[base].GetHeight()+1 {
+			t.Fatalf("version %d: new version created %d nodes over height %d", base, created, versions[base].GetHeight())
+		}
+
+		versions = append(versions, next)
+		sets = append(sets, set)
+	}
+
+	for i, pt := range versions {
+		if got, want := slices.Collect(pt.Ascending()), slices.Sorted(maps.Keys(sets[i])); !slices.Equal(got, want) {
+			t.Fatalf("version %d holds %v, want %v", i, got, want)
+		}
+
+		if pt.Size() != uint(len(sets[i])) || !pt.view().IsValid() {
+			t.Fatalf("version %d is invalid or has Size() %d", i, pt.Size())
+		}
+	}
+}
+
+func TestPersistentSharesUntouchedSubtrees(t *testing.T) {
+	pt := perfectVersion()
+	root := pt.root
+
+	added, _ := pt.Add(16)
+
+	// The path 8, 12, 14, 15 is copied, everything off it is shared
+	if added.root == root || added.root.right == root.right || added.root.right.right == root.right.right {
+		t.Error("Add did not copy the path to the new node")
+	}
+
+	if added.root.left != root.left || added.root.right.left != root.right.left || added.root.right.right.left != root.right.right.left {
+		t.Error("Add copied a subtree off the path")
+	}
+
+	// Removing 4 copies 4 and the path 6, 5 to its successor
+	removed, _ := pt.Remove(4)
+
+	if removed.root.left.data != 5 || removed.root.left == root.left || removed.root.left.right == root.left.right {
+		t.Error("Remove did not copy the path to the successor")
+	}
+
+	if removed.root.right != root.right || removed.root.left.left != root.left.left || removed.root.left.right.right != root.left.right.right {
+		t.Error("Remove copied a subtree off the path")
+	}
+
+	if original := perfectVersion(); !pt.view().IsStructurallyIdentical(original.view()) {
+		t.Error("Add and Remove changed the original version")
+	}
+}
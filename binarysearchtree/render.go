@@ -0,0 +1,176 @@
+package binarysearchtree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Annotation selects extra per-node information for RenderDOT
+type Annotation int
+
+const (
+	// AnnotateHeight labels each node with the height of its subtree
+	AnnotateHeight Annotation = iota
+	// AnnotateBalance labels each node with the height of its left subtree minus its right subtree
+	AnnotateBalance
+	// AnnotateCount labels each node with its number of occurrences
+	AnnotateCount
+)
+
+// RenderDOT returns a Graphviz DOT document of the tree shape, nodes with a single child
+// get a point shaped null marker on the missing side so left and right stay distinguishable
+func (bst *BinarySearchTree) RenderDOT(annotations ...Annotation) string {
+	bst.guard()
+
+	sb := strings.Builder{}
+	heights := make(map[*node]int)
+	subtreeHeights(bst.root, heights)
+
+	sb.WriteString("digraph BinarySearchTree {\n")
+	sb.WriteString("\tgraph [ordering=out];\n")
+	sb.WriteString("\tnode [shape=circle];\n")
+
+	id := 0
+	nullID := 0
+
+	var render func(n *node) int
+	render = func(n *node) int {
+		nodeID := id
+		id++
+
+		label := fmt.Sprintf("%d", n.data)
+
+		for _, annotation := range annotations {
+			switch annotation {
+			case AnnotateHeight:
+				label += fmt.Sprintf("\\nh=%d", heights[n])
+			case AnnotateBalance:
+				label += fmt.Sprintf("\\nb=%d", heights[n.left]-heights[n.right])
+			case AnnotateCount:
+				label += fmt.Sprintf("\\nc=%d", n.count)
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("\tn%d [label=\"%s\"];\n", nodeID, label))
+
+		for _, child := range []*node{n.left, n.right} {
+			if child != nil {
+				sb.WriteString(fmt.Sprintf("\tn%d -> n%d;\n", nodeID, render(child)))
+			} else if n.left != nil || n.right != nil {
+				sb.WriteString(fmt.Sprintf("\tnull%d [shape=point];\n", nullID))
+				sb.WriteString(fmt.Sprintf("\tn%d -> null%d;\n", nodeID, nullID))
+				nullID++
+			}
+		}
+
+		return nodeID
+	}
+
+	if bst.root != nil {
+		render(bst.root)
+	}
+
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// RenderASCII returns a multi-line drawing of the tree, elements occurring more than once are drawn as value(xcount)
+func (bst *BinarySearchTree) RenderASCII() string {
+	bst.guard()
+
+	if bst.root == nil {
+		return "nil"
+	}
+
+	lines, _, _ := drawASCII(bst.root)
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// drawASCII returns the lines of the drawing of a subtree, its width and the column of its root
+func drawASCII(n *node) ([]string, int, int) {
+	label := fmt.Sprintf("%d", n.data)
+
+	if n.count > 1 {
+		label += fmt.Sprintf("(x%d)", n.count)
+	}
+
+	labelWidth := len(label)
+
+	if n.left == nil && n.right == nil {
+		return []string{label}, labelWidth, labelWidth / 2
+	}
+
+	if n.right == nil {
+		lines, width, middle := drawASCII(n.left)
+		first := spaces(middle+1) + strings.Repeat("_", width-middle-1) + label
+		second := spaces(middle) + "/" + spaces(width-middle-1+labelWidth)
+
+		result := []string{first, second}
+
+		for _, line := range lines {
+			result = append(result, line+spaces(labelWidth))
+		}
+
+		return result, width + labelWidth, width + labelWidth/2
+	}
+
+	if n.left == nil {
+		lines, width, middle := drawASCII(n.right)
+		first := label + strings.Repeat("_", middle) + spaces(width-middle)
+		second := spaces(labelWidth+middle) + "\\" + spaces(width-middle-1)
+
+		result := []string{first, second}
+
+		for _, line := range lines {
+			result = append(result, spaces(labelWidth)+line)
+		}
+
+		return result, width + labelWidth, labelWidth / 2
+	}
+
+	leftLines, leftWidth, leftMiddle := drawASCII(n.left)
+	rightLines, rightWidth, rightMiddle := drawASCII(n.right)
+
+	first := spaces(leftMiddle+1) + strings.Repeat("_", leftWidth-leftMiddle-1) + label +
+		strings.Repeat("_", rightMiddle) + spaces(rightWidth-rightMiddle)
+	second := spaces(leftMiddle) + "/" + spaces(leftWidth-leftMiddle-1+labelWidth+rightMiddle) +
+		"\\" + spaces(rightWidth-rightMiddle-1)
+
+	for len(leftLines) < len(rightLines) {
+		leftLines = append(leftLines, spaces(leftWidth))
+	}
+
+	for len(rightLines) < len(leftLines) {
+		rightLines = append(rightLines, spaces(rightWidth))
+	}
+
+	result := []string{first, second}
+
+	for i := range leftLines {
+		result = append(result, leftLines[i]+spaces(labelWidth)+rightLines[i])
+	}
+
+	return result, leftWidth + labelWidth + rightWidth, leftWidth + labelWidth/2
+}
+
+// subtreeHeights records the height of every subtree in one postorder pass, O(n)
+func subtreeHeights(n *node, heights map[*node]int) int {
+	if n == nil {
+		return 0
+	}
+
+	h := max(subtreeHeights(n.left, heights), subtreeHeights(n.right, heights)) + 1
+	heights[n] = h
+
+	return h
+}
+
+func spaces(count int) string {
+	return strings.Repeat(" ", count)
+}
@@ -0,0 +1,107 @@
+package binarysearchtree
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderASCII(t *testing.T) {
+	multiset := NewMultiset()
+
+	for _, v := range []int{20, 10, 30, 10, 10, 30} {
+		multiset.Add(v)
+	}
+
+	tests := []struct {
+		name string
+		bst  *BinarySearchTree
+		want string
+	}{
+		{"empty", NewTree(), "nil"},
+		{"single node", FromSlice([]int{42}), "42"},
+		{"left chain", branchTree(branch(3, branch(2, leaf(1), nil), nil)), `
+  3
+ /
+ 2
+/
+1`},
+		{"right chain", degenerateTree(3), `
+1
+ \
+ 2
+  \
+  3`},
+		{"full", FromSlice([]int{1, 2, 3, 4, 5, 6, 7}), `
+  _4_
+ /   \
+ 2   6
+/ \ / \
+1 3 5 7`},
+		{"multiset", multiset, `
+    __20___
+   /       \
+10(x3)  30(x2)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := strings.TrimPrefix(tt.want, "\n")
+
+			if got := tt.bst.RenderASCII(); got != want {
+				t.Errorf("RenderASCII() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestRenderDOT(t *testing.T) {
+	tests := []struct {
+		name        string
+		bst         *BinarySearchTree
+		annotations []Annotation
+		want        string
+	}{
+		{"empty", NewTree(), nil, `digraph BinarySearchTree {
+	graph [ordering=out];
+	node [shape=circle];
+}
+`},
+		{"null markers on either side", branchTree(branch(5, branch(3, nil, leaf(4)), branch(8, leaf(7), nil))), nil, `digraph BinarySearchTree {
+	graph [ordering=out];
+	node [shape=circle];
+	n0 [label="5"];
+	n1 [label="3"];
+	null0 [shape=point];
+	n1 -> null0;
+	n2 [label="4"];
+	n1 -> n2;
+	n0 -> n1;
+	n3 [label="8"];
+	n4 [label="7"];
+	n3 -> n4;
+	null1 [shape=point];
+	n3 -> null1;
+	n0 -> n3;
+}
+`},
+		{"annotations", &BinarySearchTree{root: &node{data: 5, count: 2, left: leaf(3)}, nodeCount: 3, multiset: true},
+			[]Annotation{AnnotateHeight, AnnotateBalance, AnnotateCount}, `digraph BinarySearchTree {
+	graph [ordering=out];
+	node [shape=circle];
+	n0 [label="5\nh=2\nb=1\nc=2"];
+	n1 [label="3\nh=1\nb=0\nc=1"];
+	n0 -> n1;
+	null0 [shape=point];
+	n0 -> null0;
+}
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.bst.RenderDOT(tt.annotations...); got != tt.want {
+				t.Errorf("RenderDOT() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
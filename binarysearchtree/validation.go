@@ -0,0 +1,127 @@
+package binarysearchtree
+
+// IsValid checks the ordering invariant on every node and that the occurrence counts add up to Size, O(n)
+func (bst *BinarySearchTree) IsValid() bool {
+	bst.guard()
+
+	total, ok := bst.isValid(bst.root, nil, nil)
+	return ok && total == bst.nodeCount
+}
+
+// Equal checks if both trees hold the same elements with the same number of occurrences, shape aside, O(n)
+func (bst *BinarySearchTree) Equal(other *BinarySearchTree) bool {
+	if bst.nodeCount != other.nodeCount {
+		return false
+	}
+
+	c1 := bst.Cursor()
+	c2 := other.Cursor()
+
+	for c1.Next() {
+		if !c2.Next() || c1.Value() != c2.Value() {
+			return false
+		}
+	}
+
+	return !c2.Next()
+}
+
+// IsStructurallyIdentical checks if both trees have the same shape holding the same elements, O(n)
+func (bst *BinarySearchTree) IsStructurallyIdentical(other *BinarySearchTree) bool {
+	bst.guard()
+	other.guard()
+
+	return identical(bst.root, other.root)
+}
+
+// IsBalanced checks if the heights of the two subtrees of every node differ by at most one, O(n)
+func (bst *BinarySearchTree) IsBalanced() bool {
+	bst.guard()
+
+	return balancedHeight(bst.root) >= 0
+}
+
+// Diameter returns the number of edges on the longest path between any two nodes, O(n)
+func (bst *BinarySearchTree) Diameter() int {
+	bst.guard()
+
+	diameter := 0
+	longestPath(bst.root, &diameter)
+
+	return diameter
+}
+
+// isValid checks that every element of the subtree lies strictly between low and high, returns the occurrences it holds
+func (bst *BinarySearchTree) isValid(n *node, low, high *int) (uint, bool) {
+	if n == nil {
+		return 0, true
+	}
+
+	if low != nil && compareTo(n.data, *low) <= 0 {
+		return 0, false
+	}
+
+	if high != nil && compareTo(n.data, *high) >= 0 {
+		return 0, false
+	}
+
+	if n.count == 0 || (!bst.multiset && n.count > 1) {
+		return 0, false
+	}
+
+	leftTotal, ok := bst.isValid(n.left, low, &n.data)
+	if !ok {
+		return 0, false
+	}
+
+	rightTotal, ok := bst.isValid(n.right, &n.data, high)
+	if !ok {
+		return 0, false
+	}
+
+	return leftTotal + rightTotal + n.count, true
+}
+
+func identical(x, y *node) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+
+	return x.data == y.data && x.count == y.count && identical(x.left, y.left) && identical(x.right, y.right)
+}
+
+// balancedHeight returns the height of a balanced subtree, -1 as soon as an unbalanced node is found
+func balancedHeight(n *node) int {
+	if n == nil {
+		return 0
+	}
+
+	leftHeight := balancedHeight(n.left)
+	if leftHeight < 0 {
+		return -1
+	}
+
+	rightHeight := balancedHeight(n.right)
+	if rightHeight < 0 {
+		return -1
+	}
+
+	if leftHeight-rightHeight > 1 || rightHeight-leftHeight > 1 {
+		return -1
+	}
+
+	return max(leftHeight, rightHeight) + 1
+}
+
+// longestPath returns the height of the subtree and records the longest path through its root in diameter
+func longestPath(n *node, diameter *int) int {
+	if n == nil {
+		return 0
+	}
+
+	leftHeight := longestPath(n.left, diameter)
+	rightHeight := longestPath(n.right, diameter)
+	*diameter = max(*diameter, leftHeight+rightHeight)
+
+	return max(leftHeight, rightHeight) + 1
+}
@@ -0,0 +1,132 @@
+package binarysearchtree
+
+import "testing"
+
+// leaf returns a node holding element once
+func leaf(element int) *node {
+	return &node{data: element, count: 1}
+}
+
+// branch returns a node holding element once over the given children
+func branch(element int, left, right *node) *node {
+	return &node{data: element, count: 1, left: left, right: right}
+}
+
+// degenerateTree returns the right leaning list 1..n
+func degenerateTree(n int) *BinarySearchTree {
+	bst := NewTree()
+
+	for i := 1; i <= n; i++ {
+		bst.Add(i)
+	}
+
+	return bst
+}
+
+func TestIsValid(t *testing.T) {
+	multiset := &node{data: 5, count: 3, left: leaf(2)}
+
+	tests := []struct {
+		name string
+		bst  *BinarySearchTree
+		want bool
+	}{
+		{"empty", NewTree(), true},
+		{"balanced", FromSlice([]int{1, 2, 3, 4, 5, 6, 7}), true},
+		{"degenerate", degenerateTree(20), true},
+		{"multiset counts", &BinarySearchTree{root: multiset, nodeCount: 4, multiset: true}, true},
+		{"left child greater", &BinarySearchTree{root: branch(5, leaf(7), nil), nodeCount: 2}, false},
+		{"right child smaller", &BinarySearchTree{root: branch(5, nil, leaf(3)), nodeCount: 2}, false},
+		{"grandchild beyond the root", &BinarySearchTree{root: branch(8, branch(4, nil, leaf(9)), nil), nodeCount: 3}, false},
+		{"duplicate node", &BinarySearchTree{root: branch(5, leaf(5), nil), nodeCount: 2}, false},
+		{"nodeCount too small", &BinarySearchTree{root: branch(5, leaf(3), nil), nodeCount: 1}, false},
+		{"nodeCount too large", &BinarySearchTree{root: branch(5, leaf(3), nil), nodeCount: 3}, false},
+		{"nodeCount on an empty tree", &BinarySearchTree{nodeCount: 1}, false},
+		{"zero count", &BinarySearchTree{root: &node{data: 5}, multiset: true}, false},
+		{"count above 1 outside a multiset", &BinarySearchTree{root: &node{data: 5, count: 2}, nodeCount: 2}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.bst.IsValid(); got != tt.want {
+				t.Errorf("IsValid() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsStructurallyIdentical(t *testing.T) {
+	twice := NewMultiset()
+	twice.Add(2)
+	twice.Add(1)
+	twice.Add(2)
+
+	once := NewMultiset()
+	once.Add(2)
+	once.Add(1)
+
+	tests := []struct {
+		name string
+		a, b *BinarySearchTree
+		want bool
+	}{
+		{"both empty", NewTree(), NewTree(), true},
+		{"same shape", FromSlice([]int{1, 2, 3}), branchTree(branch(2, leaf(1), leaf(3))), true},
+		{"same elements other shape", FromSlice([]int{1, 2, 3}), degenerateTree(3), false},
+		{"other elements same shape", FromSlice([]int{1, 2, 3}), FromSlice([]int{1, 2, 4}), false},
+		{"empty and not empty", NewTree(), FromSlice([]int{1}), false},
+		{"other counts", twice, once, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.IsStructurallyIdentical(tt.b); got != tt.want {
+				t.Errorf("IsStructurallyIdentical() = %t, want %t", got, tt.want)
+			}
+
+			if got := tt.b.IsStructurallyIdentical(tt.a); got != tt.want {
+				t.Errorf("IsStructurallyIdentical() reversed = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBalancedAndDiameter(t *testing.T) {
+	tests := []struct {
+		name     string
+		bst      *BinarySearchTree
+		balanced bool
+		diameter int
+	}{
+		{"empty", NewTree(), true, 0},
+		{"single node", FromSlice([]int{1}), true, 0},
+		{"two nodes", degenerateTree(2), true, 1},
+		{"three node list", degenerateTree(3), false, 2},
+		{"long list", degenerateTree(10), false, 9},
+		{"perfect", FromSlice([]int{1, 2, 3, 4, 5, 6, 7}), true, 4},
+		// The root is balanced, its left child is not
+		{"unbalanced below the root", branchTree(branch(10, branch(4, branch(2, leaf(1), nil), nil), branch(12, nil, leaf(13)))), false, 5},
+		// The longest path stays inside the left subtree
+		{"diameter off the root", branchTree(branch(20, branch(10, branch(5, leaf(1), nil), branch(15, nil, leaf(17))), nil)), false, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.bst.IsBalanced(); got != tt.balanced {
+				t.Errorf("IsBalanced() = %t, want %t", got, tt.balanced)
+			}
+
+			if got := tt.bst.Diameter(); got != tt.diameter {
+				t.Errorf("Diameter() = %d, want %d", got, tt.diameter)
+			}
+		})
+	}
+}
+
+// branchTree wraps a valid hand-built subtree of single occurrences in a tree
+func branchTree(root *node) *BinarySearchTree {
+	bst := &BinarySearchTree{root: root}
+	bst.nodeCount, _ = bst.isValid(root, nil, nil)
+
+	return bst
+}
@@ -0,0 +1,245 @@
+package binarysearchtree
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// Order represents a traversal order for Walk and Collect
+type Order int
+
+const (
+	PreOrder Order = iota
+	InOrder
+	PostOrder
+	LevelOrder
+	// MorrisInOrder walks in inorder with O(1) extra space by temporarily threading the tree
+	MorrisInOrder
+	// MorrisPreOrder walks in preorder with O(1) extra space by temporarily threading the tree
+	MorrisPreOrder
+)
+
+var orderNames = map[Order]string{
+	PreOrder:       "preorder",
+	InOrder:        "inorder",
+	PostOrder:      "postorder",
+	LevelOrder:     "levelorder",
+	MorrisInOrder:  "morris-inorder",
+	MorrisPreOrder: "morris-preorder",
+}
+
+// ErrModified is returned by Walk and Cursor.Err, and raised by the iterators, when the
+// tree is modified while it is traversed
+var ErrModified = errors.New("modification detected during iteration")
+
+// Visitor is called once per occurrence of every element with its depth, the root
+// being at depth 0, returning false stops the walk
+type Visitor func(value int, depth int) bool
+
+// frame represents a node waiting on a traversal stack or queue with its depth
+type frame struct {
+	node  *node
+	depth int
+}
+
+func (o Order) String() string {
+	if name, ok := orderNames[o]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// ParseOrder returns the Order named by one of the PrintTree order strings
+func ParseOrder(name string) (Order, error) {
+	for order, orderName := range orderNames {
+		if orderName == name {
+			return order, nil
+		}
+	}
+
+	return 0, errors.New("order is invalid")
+}
+
+// Walk calls visit for every element in the given order until visit returns false,
+// the tree must not be modified from visit. The Morris orders temporarily write to the
+// nodes, so on nodes shared with other versions they fall back to the stack walks, and
+// on other trees they are not safe alongside other readers. While a Morris walk runs the
+// tree refuses every other use: Walk and cursors return ErrModified, other methods panic
+// with it, and a visit that calls one of them stops the walk with ErrModified
+func (bst *BinarySearchTree) Walk(order Order, visit Visitor) error {
+	if atomic.LoadUint32(&bst.threading) != 0 {
+		return ErrModified
+	}
+
+	expectedModCount := bst.modCount
+	modified := false
+
+	// guarded stops the walk as soon as visit modifies the tree
+	guarded := func(n *node, depth int) bool {
+		for i := uint(0); i < n.count; i++ {
+			if !visit(n.data, depth) {
+				return false
+			}
+
+			if expectedModCount != bst.modCount {
+				modified = true
+				return false
+			}
+		}
+
+		return true
+	}
+
+	switch order {
+	case PreOrder:
+		bst.walkPreorder(guarded)
+	case InOrder:
+		bst.walkInorder(guarded)
+	case PostOrder:
+		bst.walkPostorder(guarded)
+	case LevelOrder:
+		bst.walkLevelorder(guarded)
+	case MorrisInOrder:
+		if bst.shared {
+			bst.walkInorder(guarded)
+		} else if err := bst.walkMorris(false, guarded); err != nil {
+			return err
+		}
+	case MorrisPreOrder:
+		if bst.shared {
+			bst.walkPreorder(guarded)
+		} else if err := bst.walkMorris(true, guarded); err != nil {
+			return err
+		}
+	default:
+		return errors.New("order is invalid")
+	}
+
+	if modified {
+		return ErrModified
+	}
+
+	return nil
+}
+
+// Collect returns every element in the given order
+func (bst *BinarySearchTree) Collect(order Order) ([]int, error) {
+	var data []int
+
+	err := bst.Walk(order, func(value int, depth int) bool {
+		data = append(data, value)
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (bst *BinarySearchTree) walkPreorder(visit func(n *node, depth int) bool) {
+	if bst.root == nil {
+		return
+	}
+
+	stack := []frame{{node: bst.root}}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !visit(current.node, current.depth) {
+			return
+		}
+
+		if current.node.right != nil {
+			stack = append(stack, frame{node: current.node.right, depth: current.depth + 1})
+		}
+
+		if current.node.left != nil {
+			stack = append(stack, frame{node: current.node.left, depth: current.depth + 1})
+		}
+	}
+}
+
+func (bst *BinarySearchTree) walkInorder(visit func(n *node, depth int) bool) {
+	var stack []frame
+	travNode := bst.root
+	depth := 0
+
+	for travNode != nil || len(stack) > 0 {
+		for ; travNode != nil; travNode = travNode.left {
+			stack = append(stack, frame{node: travNode, depth: depth})
+			depth++
+		}
+
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !visit(current.node, current.depth) {
+			return
+		}
+
+		travNode = current.node.right
+		depth = current.depth + 1
+	}
+}
+
+// walkPostorder uses a single stack, a node is visited once its right subtree was
+func (bst *BinarySearchTree) walkPostorder(visit func(n *node, depth int) bool) {
+	var stack []frame
+	var lastVisited *node
+	travNode := bst.root
+	depth := 0
+
+	for travNode != nil || len(stack) > 0 {
+		if travNode != nil {
+			stack = append(stack, frame{node: travNode, depth: depth})
+			travNode = travNode.left
+			depth++
+			continue
+		}
+
+		top := stack[len(stack)-1]
+
+		if top.node.right != nil && top.node.right != lastVisited {
+			travNode = top.node.right
+			depth = top.depth + 1
+			continue
+		}
+
+		stack = stack[:len(stack)-1]
+		lastVisited = top.node
+
+		if !visit(top.node, top.depth) {
+			return
+		}
+	}
+}
+
+func (bst *BinarySearchTree) walkLevelorder(visit func(n *node, depth int) bool) {
+	if bst.root == nil {
+		return
+	}
+
+	queue := []frame{{node: bst.root}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if !visit(current.node, current.depth) {
+			return
+		}
+
+		if current.node.left != nil {
+			queue = append(queue, frame{node: current.node.left, depth: current.depth + 1})
+		}
+
+		if current.node.right != nil {
+			queue = append(queue, frame{node: current.node.right, depth: current.depth + 1})
+		}
+	}
+}
@@ -0,0 +1,576 @@
+package bplustree
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/seonicklaus/data-structures-go/binarysearchtree"
+)
+
+// BPlusTree represents a disk-backed ordered index, every key is stored in a leaf page
+// together with its value, internal pages only route searches and leaves are linked
+// in key order for range scans. Every Insert and Delete is committed before returning
+type BPlusTree struct {
+	pager *pager
+	meta  meta
+	err   error
+}
+
+// split represents the separator key and page of the new right sibling of a split node
+type split struct {
+	key   int
+	right uint64
+}
+
+var _ binarysearchtree.OrderedSet = (*BPlusTree)(nil)
+
+// Open opens the B+ tree stored in the file at path, creating it with pageSize bytes per
+// page when it does not exist, a pageSize of 0 uses the default page size or the one of the file
+func Open(path string, pageSize int) (*BPlusTree, error) {
+	p, err := openPager(path, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &BPlusTree{pager: p}
+
+	if p.pageCount == 0 {
+		err = t.create()
+	} else {
+		err = t.load()
+	}
+
+	if err != nil {
+		p.close()
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *BPlusTree) Close() error {
+	return t.pager.close()
+}
+
+func (t *BPlusTree) Size() uint {
+	return uint(t.meta.size)
+}
+
+func (t *BPlusTree) IsEmpty() bool {
+	return t.meta.size == 0
+}
+
+// GetHeight returns the number of levels, a tree holding a single leaf has height 1, and
+// like every OrderedSet an empty tree has height 0 even though its root leaf exists
+func (t *BPlusTree) GetHeight() int {
+	if t.IsEmpty() {
+		return 0
+	}
+
+	return int(t.meta.height)
+}
+
+// Err returns the first I/O error met by Contains, Add, Remove, which cannot return one
+func (t *BPlusTree) Err() error {
+	return t.err
+}
+
+// Get returns the value stored under key, O(log n)
+func (t *BPlusTree) Get(key int) ([]byte, bool, error) {
+	n, err := t.findLeaf(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	i, found := slices.BinarySearch(n.keys, key)
+	if !found {
+		return nil, false, nil
+	}
+
+	return n.values[i], true, nil
+}
+
+// Insert stores value under key, replacing the existing value, returns false if key was already present, O(log n)
+func (t *BPlusTree) Insert(key int, value []byte) (bool, error) {
+	if len(value) > maxValueSize(t.pager.pageSize) {
+		return false, errors.New("value too large for page size")
+	}
+
+	inserted := false
+
+	err := t.update(func() error {
+		var s *split
+		var err error
+
+		inserted, s, err = t.insert(t.meta.root, key, value)
+		if err != nil {
+			return err
+		}
+
+		if inserted {
+			t.meta.size++
+		}
+
+		if s == nil {
+			return nil
+		}
+
+		root, err := t.allocateNode(false)
+		if err != nil {
+			return err
+		}
+
+		root.keys = []int{s.key}
+		root.children = []uint64{t.meta.root, s.right}
+		t.meta.root = root.id
+		t.meta.height++
+
+		return t.writeNode(root)
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return inserted, nil
+}
+
+// Delete removes key and its value, returns false if key was not present, O(log n)
+func (t *BPlusTree) Delete(key int) (bool, error) {
+	deleted := false
+
+	err := t.update(func() error {
+		var err error
+
+		deleted, _, err = t.remove(t.meta.root, key)
+		if err != nil || !deleted {
+			return err
+		}
+
+		t.meta.size--
+
+		return t.collapseRoot()
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
+
+// Range calls fn for every key in [from, to) in ascending order until fn returns false, O(log n + k)
+func (t *BPlusTree) Range(from, to int, fn func(key int, value []byte) bool) error {
+	n, err := t.findLeaf(from)
+	if err != nil {
+		return err
+	}
+
+	i, _ := slices.BinarySearch(n.keys, from)
+
+	for {
+		for ; i < len(n.keys); i++ {
+			if n.keys[i] >= to || !fn(n.keys[i], n.values[i]) {
+				return nil
+			}
+		}
+
+		if n.next == 0 {
+			return nil
+		}
+
+		if n, err = t.readNode(n.next); err != nil {
+			return err
+		}
+
+		i = 0
+	}
+}
+
+// Contains checks if key is present, I/O errors are reported by Err
+func (t *BPlusTree) Contains(element int) bool {
+	_, found, err := t.Get(element)
+	t.record(err)
+
+	return found
+}
+
+// Add inserts key with an empty value, I/O errors are reported by Err
+func (t *BPlusTree) Add(element int) bool {
+	if t.Contains(element) {
+		return false
+	}
+
+	inserted, err := t.Insert(element, nil)
+	t.record(err)
+
+	return inserted
+}
+
+// Remove deletes key, I/O errors are reported by Err
+func (t *BPlusTree) Remove(element int) bool {
+	deleted, err := t.Delete(element)
+	t.record(err)
+
+	return deleted
+}
+
+// PrintTree returns the keys in the order named by one of "preorder", "inorder", "postorder" or
+// "levelorder" by scanning the linked leaves. Internal pages only hold copies of separator
+// keys, so every element lives in a leaf and every leaf lies at the same depth, which makes
+// each of these orders visit the leaves from left to right, all of them return sorted keys
+func (t *BPlusTree) PrintTree(order string) ([]int, error) {
+	switch order {
+	case "preorder", "inorder", "postorder", "levelorder":
+	default:
+		return nil, errors.New("order is invalid")
+	}
+
+	var data []int
+
+	n, err := t.readNode(t.meta.root)
+
+	for err == nil && !n.leaf {
+		n, err = t.readNode(n.children[0])
+	}
+
+	for err == nil {
+		data = append(data, n.keys...)
+
+		if n.next == 0 {
+			return data, nil
+		}
+
+		n, err = t.readNode(n.next)
+	}
+
+	return nil, err
+}
+
+// insert returns whether key is new and, when n had to split, its separator and new right sibling
+func (t *BPlusTree) insert(id uint64, key int, value []byte) (bool, *split, error) {
+	n, err := t.readNode(id)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if n.leaf {
+		i, found := slices.BinarySearch(n.keys, key)
+
+		if found {
+			n.values[i] = value
+		} else {
+			n.keys = slices.Insert(n.keys, i, key)
+			n.values = slices.Insert(n.values, i, value)
+		}
+
+		if n.encodedSize() <= t.pager.pageSize {
+			return !found, nil, t.writeNode(n)
+		}
+
+		s, err := t.splitLeaf(n)
+		return !found, s, err
+	}
+
+	i := childIndex(n.keys, key)
+
+	inserted, s, err := t.insert(n.children[i], key, value)
+	if err != nil || s == nil {
+		return inserted, nil, err
+	}
+
+	n.keys = slices.Insert(n.keys, i, s.key)
+	n.children = slices.Insert(n.children, i+1, s.right)
+
+	if len(n.keys) <= maxInternalKeys(t.pager.pageSize) {
+		return inserted, nil, t.writeNode(n)
+	}
+
+	s, err = t.splitInternal(n)
+	return inserted, s, err
+}
+
+// splitLeaf moves the upper half of the entries, by bytes, into a new leaf linked after n
+func (t *BPlusTree) splitLeaf(n *node) (*split, error) {
+	right, err := t.allocateNode(true)
+	if err != nil {
+		return nil, err
+	}
+
+	half := (n.encodedSize() - nodeHeaderSize) / 2
+	bytes := 0
+	middle := 0
+
+	for middle < len(n.keys)-1 && bytes < half {
+		bytes += leafEntryOverhead + len(n.values[middle])
+		middle++
+	}
+
+	right.keys = slices.Clone(n.keys[middle:])
+	right.values = slices.Clone(n.values[middle:])
+	right.next = n.next
+	n.keys = n.keys[:middle]
+	n.values = n.values[:middle]
+	n.next = right.id
+
+	if err := t.writeNode(n); err != nil {
+		return nil, err
+	}
+
+	return &split{key: right.keys[0], right: right.id}, t.writeNode(right)
+}
+
+// splitInternal moves the upper half of the keys into a new internal node, promoting the middle key
+func (t *BPlusTree) splitInternal(n *node) (*split, error) {
+	right, err := t.allocateNode(false)
+	if err != nil {
+		return nil, err
+	}
+
+	middle := len(n.keys) / 2
+	promoted := n.keys[middle]
+
+	right.keys = slices.Clone(n.keys[middle+1:])
+	right.children = slices.Clone(n.children[middle+1:])
+	n.keys = n.keys[:middle]
+	n.children = n.children[:middle+1]
+
+	if err := t.writeNode(n); err != nil {
+		return nil, err
+	}
+
+	return &split{key: promoted, right: right.id}, t.writeNode(right)
+}
+
+// remove returns whether key was found and whether n is left underfull
+func (t *BPlusTree) remove(id uint64, key int) (bool, bool, error) {
+	n, err := t.readNode(id)
+	if err != nil {
+		return false, false, err
+	}
+
+	if n.leaf {
+		i, found := slices.BinarySearch(n.keys, key)
+		if !found {
+			return false, false, nil
+		}
+
+		n.keys = slices.Delete(n.keys, i, i+1)
+		n.values = slices.Delete(n.values, i, i+1)
+
+		return true, t.underfull(n), t.writeNode(n)
+	}
+
+	i := childIndex(n.keys, key)
+
+	found, underfull, err := t.remove(n.children[i], key)
+	if err != nil || !found || !underfull {
+		return found, false, err
+	}
+
+	merged, err := t.mergeChild(n, i)
+	if err != nil || !merged {
+		return true, false, err
+	}
+
+	return true, t.underfull(n), t.writeNode(n)
+}
+
+// mergeChild merges the underfull child i of parent with a neighbour when both fit in one page,
+// the right one of the pair is freed and its separator removed from parent
+func (t *BPlusTree) mergeChild(parent *node, i int) (bool, error) {
+	for _, left := range []int{i - 1, i} {
+		if left < 0 || left+1 >= len(parent.children) {
+			continue
+		}
+
+		leftNode, err := t.readNode(parent.children[left])
+		if err != nil {
+			return false, err
+		}
+
+		rightNode, err := t.readNode(parent.children[left+1])
+		if err != nil {
+			return false, err
+		}
+
+		if leftNode.leaf {
+			if leftNode.encodedSize()+rightNode.encodedSize()-nodeHeaderSize > t.pager.pageSize {
+				continue
+			}
+
+			leftNode.keys = append(leftNode.keys, rightNode.keys...)
+			leftNode.values = append(leftNode.values, rightNode.values...)
+			leftNode.next = rightNode.next
+		} else {
+			if len(leftNode.keys)+len(rightNode.keys)+1 > maxInternalKeys(t.pager.pageSize) {
+				continue
+			}
+
+			leftNode.keys = append(append(leftNode.keys, parent.keys[left]), rightNode.keys...)
+			leftNode.children = append(leftNode.children, rightNode.children...)
+		}
+
+		parent.keys = slices.Delete(parent.keys, left, left+1)
+		parent.children = slices.Delete(parent.children, left+1, left+2)
+
+		if err := t.writeNode(leftNode); err != nil {
+			return false, err
+		}
+
+		return true, t.freeNode(rightNode.id)
+	}
+
+	return false, nil
+}
+
+// collapseRoot replaces an internal root left with a single child by that child
+func (t *BPlusTree) collapseRoot() error {
+	root, err := t.readNode(t.meta.root)
+	if err != nil {
+		return err
+	}
+
+	if root.leaf || len(root.keys) > 0 {
+		return nil
+	}
+
+	t.meta.root = root.children[0]
+	t.meta.height--
+
+	return t.freeNode(root.id)
+}
+
+func (t *BPlusTree) underfull(n *node) bool {
+	if n.leaf {
+		return n.encodedSize() < t.pager.pageSize/4
+	}
+
+	return len(n.keys) < maxInternalKeys(t.pager.pageSize)/4
+}
+
+func (t *BPlusTree) findLeaf(key int) (*node, error) {
+	n, err := t.readNode(t.meta.root)
+
+	for err == nil && !n.leaf {
+		n, err = t.readNode(n.children[childIndex(n.keys, key)])
+	}
+
+	return n, err
+}
+
+// update runs change as one transaction, committing it with the meta page or rolling everything back
+func (t *BPlusTree) update(change func() error) error {
+	saved := t.meta
+	err := change()
+
+	if err == nil && t.meta != saved {
+		err = t.pager.write(metaPageID, t.meta.encode(t.pager.pageSize))
+	}
+
+	if err == nil {
+		err = t.pager.commit()
+	} else {
+		t.pager.rollback()
+	}
+
+	if err != nil {
+		t.meta = saved
+	}
+
+	return err
+}
+
+func (t *BPlusTree) create() error {
+	t.pager.allocate()
+	root := t.pager.allocate()
+
+	t.meta = meta{pageSize: uint32(t.pager.pageSize), root: root, height: 1}
+
+	return t.update(func() error {
+		if err := t.pager.write(metaPageID, t.meta.encode(t.pager.pageSize)); err != nil {
+			return err
+		}
+
+		return t.writeNode(&node{id: root, leaf: true})
+	})
+}
+
+func (t *BPlusTree) load() error {
+	page, err := t.pager.read(metaPageID)
+	if err != nil {
+		return err
+	}
+
+	m, err := decodeMeta(page)
+	if err != nil {
+		return err
+	}
+
+	t.meta = *m
+
+	return nil
+}
+
+func (t *BPlusTree) readNode(id uint64) (*node, error) {
+	page, err := t.pager.read(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeNode(id, page)
+}
+
+func (t *BPlusTree) writeNode(n *node) error {
+	return t.pager.write(n.id, n.encode(t.pager.pageSize))
+}
+
+// allocateNode reuses the first free page, or grows the file
+func (t *BPlusTree) allocateNode(leaf bool) (*node, error) {
+	if t.meta.freeHead == 0 {
+		return &node{id: t.pager.allocate(), leaf: leaf}, nil
+	}
+
+	page, err := t.pager.read(t.meta.freeHead)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := decodeFree(page)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &node{id: t.meta.freeHead, leaf: leaf}
+	t.meta.freeHead = next
+
+	return n, nil
+}
+
+func (t *BPlusTree) freeNode(id uint64) error {
+	if err := t.pager.write(id, encodeFree(t.meta.freeHead, t.pager.pageSize)); err != nil {
+		return err
+	}
+
+	t.meta.freeHead = id
+
+	return nil
+}
+
+// record keeps the first error for Err
+func (t *BPlusTree) record(err error) {
+	if err != nil && t.err == nil {
+		t.err = err
+	}
+}
+
+// childIndex returns the child that holds key, the first child whose separator is greater than key
+func childIndex(keys []int, key int) int {
+	i, found := slices.BinarySearch(keys, key)
+
+	if found {
+		i++
+	}
+
+	return i
+}
@@ -0,0 +1,428 @@
+package bplustree
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const testPageSize = 128
+
+func value(key int) []byte {
+	return []byte(fmt.Sprintf("v%d", key))
+}
+
+func openTree(t *testing.T, path string, pageSize int) *BPlusTree {
+	t.Helper()
+
+	tree, err := Open(path, pageSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		tree.Close()
+	})
+
+	return tree
+}
+
+// fill inserts every key with its value and returns the expected content
+func fill(t *testing.T, tree *BPlusTree, keys []int) map[int][]byte {
+	t.Helper()
+
+	want := make(map[int][]byte)
+
+	for _, key := range keys {
+		if _, err := tree.Insert(key, value(key)); err != nil {
+			t.Fatal(err)
+		}
+
+		want[key] = value(key)
+	}
+
+	return want
+}
+
+// checkTree verifies the content against want and the shape of every page
+func checkTree(t *testing.T, tree *BPlusTree, want map[int][]byte) {
+	t.Helper()
+
+	if tree.Size() != uint(len(want)) {
+		t.Fatalf("Size() = %d, want %d", tree.Size(), len(want))
+	}
+
+	keys, err := tree.PrintTree("inorder")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if wantKeys := slices.Sorted(maps.Keys(want)); !slices.Equal(keys, wantKeys) {
+		t.Fatalf("keys = %v, want %v", keys, wantKeys)
+	}
+
+	for key, wantValue := range want {
+		got, found, err := tree.Get(key)
+		if err != nil || !found || !bytes.Equal(got, wantValue) {
+			t.Fatalf("Get(%d) = %q, %v, %v, want %q", key, got, found, err, wantValue)
+		}
+	}
+
+	checkNode(t, tree, tree.meta.root, nil, nil, 1)
+}
+
+// checkNode verifies that the keys of a page are sorted within [low, high) and every leaf lies at the tree height
+func checkNode(t *testing.T, tree *BPlusTree, id uint64, low, high *int, depth int) {
+	t.Helper()
+
+	n, err := tree.readNode(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, key := range n.keys {
+		if (low != nil && key < *low) || (high != nil && key >= *high) || (i > 0 && n.keys[i-1] >= key) {
+			t.Fatalf("page %d keys %v out of order or outside their separators", id, n.keys)
+		}
+	}
+
+	if n.leaf {
+		if depth != int(tree.meta.height) {
+			t.Fatalf("leaf %d at depth %d, height %d", id, depth, tree.meta.height)
+		}
+
+		return
+	}
+
+	for i, child := range n.children {
+		childLow, childHigh := low, high
+
+		if i > 0 {
+			childLow = &n.keys[i-1]
+		}
+
+		if i < len(n.keys) {
+			childHigh = &n.keys[i]
+		}
+
+		checkNode(t, tree, child, childLow, childHigh, depth+1)
+	}
+}
+
+func freeListLength(t *testing.T, tree *BPlusTree) int {
+	t.Helper()
+
+	length := 0
+
+	for id := tree.meta.freeHead; id != 0; length++ {
+		page, err := tree.pager.read(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if id, err = decodeFree(page); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return length
+}
+
+func sequence(from, to int) []int {
+	var keys []int
+
+	for key := from; key < to; key++ {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// interruptCommit journals garbage over every other page and a few new ones, then writes the
+// first written dirty pages to the file and closes it, as if the process died mid commit
+func interruptCommit(t *testing.T, tree *BPlusTree, written int) {
+	t.Helper()
+
+	p := tree.pager
+	garbage := bytes.Repeat([]byte{0xAB}, p.pageSize)
+
+	for id := uint64(0); id < p.pageCount; id += 2 {
+		if err := p.write(id, garbage); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		p.write(p.allocate(), garbage)
+	}
+
+	if err := p.writeJournal(); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := slices.Sorted(maps.Keys(p.dirty))
+
+	for _, id := range ids[:min(written, len(ids))] {
+		if _, err := p.file.WriteAt(p.cache[id], int64(id)*int64(p.pageSize)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := p.file.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func journalExists(path string) bool {
+	_, err := os.Stat(path + journalSuffix)
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+func TestRecoverReplaysCompleteJournal(t *testing.T) {
+	for _, written := range []int{0, 3, 1 << 30} {
+		t.Run(fmt.Sprintf("written=%d", written), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tree.db")
+			tree := openTree(t, path, testPageSize)
+			want := fill(t, tree, sequence(0, 200))
+			pageCount := tree.pager.pageCount
+
+			interruptCommit(t, tree, written)
+
+			if !journalExists(path) {
+				t.Fatal("no journal left behind by the interrupted commit")
+			}
+
+			tree = openTree(t, path, 0)
+			checkTree(t, tree, want)
+
+			if journalExists(path) {
+				t.Fatal("journal not removed after recovery")
+			}
+
+			if tree.pager.pageCount != pageCount {
+				t.Fatalf("%d pages after recovery, want %d", tree.pager.pageCount, pageCount)
+			}
+		})
+	}
+}
+
+func TestRecoverDropsIncompleteJournal(t *testing.T) {
+	corruptions := map[string]func(journal []byte) []byte{
+		"torn": func(journal []byte) []byte {
+			return journal[:len(journal)-10]
+		},
+		"bad checksum": func(journal []byte) []byte {
+			// Inside the first original page, replaying it would corrupt the file
+			journal[20+8+nodeHeaderSize] ^= 0xFF
+			return journal
+		},
+		"bad magic": func(journal []byte) []byte {
+			journal[0] = 'X'
+			return journal
+		},
+	}
+
+	for name, corrupt := range corruptions {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tree.db")
+			tree := openTree(t, path, testPageSize)
+			want := fill(t, tree, sequence(0, 200))
+
+			// The journal was never completed, so the commit never touched the file
+			interruptCommit(t, tree, 0)
+
+			journal, err := os.ReadFile(path + journalSuffix)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.WriteFile(path+journalSuffix, corrupt(journal), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			tree = openTree(t, path, 0)
+			checkTree(t, tree, want)
+
+			if journalExists(path) {
+				t.Fatal("incomplete journal not removed")
+			}
+		})
+	}
+}
+
+func TestDataSurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	tree := openTree(t, path, testPageSize)
+	want := fill(t, tree, sequence(-300, 300))
+
+	for key := -300; key < 300; key += 3 {
+		if deleted, err := tree.Delete(key); err != nil || !deleted {
+			t.Fatalf("Delete(%d) = %v, %v", key, deleted, err)
+		}
+
+		delete(want, key)
+	}
+
+	if _, err := tree.Insert(7, []byte("replaced")); err != nil {
+		t.Fatal(err)
+	}
+
+	want[7] = []byte("replaced")
+
+	if err := tree.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tree = openTree(t, path, 0)
+	checkTree(t, tree, want)
+
+	if tree.pager.pageSize != testPageSize {
+		t.Fatalf("page size %d after reopening, want %d", tree.pager.pageSize, testPageSize)
+	}
+}
+
+func TestOpenRejectsDifferentPageSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	tree := openTree(t, path, testPageSize)
+	fill(t, tree, sequence(0, 10))
+	tree.Close()
+
+	if other, err := Open(path, 2*testPageSize); err == nil {
+		other.Close()
+		t.Fatal("Open with a different page size returned no error")
+	}
+
+	if other, err := Open(path, testPageSize); err != nil {
+		t.Fatalf("Open with the stored page size: %v", err)
+	} else {
+		other.Close()
+	}
+}
+
+func TestGetAndInsertReplace(t *testing.T) {
+	tree := openTree(t, filepath.Join(t.TempDir(), "tree.db"), testPageSize)
+	want := fill(t, tree, []int{5, 1, 9, 3})
+
+	if _, found, err := tree.Get(4); found || err != nil {
+		t.Fatalf("Get(4) found = %v, %v, want not found", found, err)
+	}
+
+	inserted, err := tree.Insert(9, []byte("nine"))
+	if err != nil || inserted {
+		t.Fatalf("Insert over an existing key = %v, %v, want false", inserted, err)
+	}
+
+	want[9] = []byte("nine")
+	checkTree(t, tree, want)
+}
+
+func TestRange(t *testing.T) {
+	tree := openTree(t, filepath.Join(t.TempDir(), "tree.db"), testPageSize)
+	fill(t, tree, sequence(0, 500))
+
+	if tree.GetHeight() < 2 {
+		t.Fatalf("height %d, the keys should span several leaves", tree.GetHeight())
+	}
+
+	tests := []struct {
+		name     string
+		from, to int
+		limit    int
+		want     []int
+	}{
+		{"across leaves", 40, 160, 0, sequence(40, 160)},
+		{"stops at to", 490, 1000, 0, sequence(490, 500)},
+		{"before the first key", -50, 3, 0, sequence(0, 3)},
+		{"empty range", 200, 200, 0, nil},
+		{"past the last key", 600, 700, 0, nil},
+		{"fn returns false", 100, 400, 25, sequence(100, 125)},
+	}
+
+	for _, test := range tests {
+		var got []int
+
+		err := tree.Range(test.from, test.to, func(key int, v []byte) bool {
+			if !bytes.Equal(v, value(key)) {
+				t.Errorf("%s: value %q under %d", test.name, v, key)
+			}
+
+			got = append(got, key)
+
+			return test.limit == 0 || len(got) < test.limit
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(got, test.want) {
+			t.Errorf("%s: Range(%d, %d) = %v, want %v", test.name, test.from, test.to, got, test.want)
+		}
+	}
+}
+
+func TestDeleteMergesAndReusesFreePages(t *testing.T) {
+	tree := openTree(t, filepath.Join(t.TempDir(), "tree.db"), testPageSize)
+	want := fill(t, tree, sequence(0, 1000))
+	height := tree.GetHeight()
+
+	for key := 0; key < 980; key++ {
+		if deleted, err := tree.Delete(key); err != nil || !deleted {
+			t.Fatalf("Delete(%d) = %v, %v", key, deleted, err)
+		}
+
+		delete(want, key)
+	}
+
+	checkTree(t, tree, want)
+
+	if tree.GetHeight() >= height {
+		t.Fatalf("height %d after deleting most keys, was %d", tree.GetHeight(), height)
+	}
+
+	free := freeListLength(t, tree)
+	if free == 0 {
+		t.Fatal("merged pages were not added to the free list")
+	}
+
+	pageCount := tree.pager.pageCount
+
+	// A single Insert allocates at most two pages, a split leaf and a new root
+	for key := 1000; freeListLength(t, tree) >= 2; key++ {
+		if _, err := tree.Insert(key, value(key)); err != nil {
+			t.Fatal(err)
+		}
+
+		want[key] = value(key)
+
+		if tree.pager.pageCount != pageCount {
+			t.Fatalf("file grew to %d pages with free pages left", tree.pager.pageCount)
+		}
+	}
+
+	checkTree(t, tree, want)
+}
+
+func TestInsertRejectsOversizedValue(t *testing.T) {
+	tree := openTree(t, filepath.Join(t.TempDir(), "tree.db"), testPageSize)
+	want := fill(t, tree, sequence(0, 20))
+	limit := maxValueSize(testPageSize)
+
+	if _, err := tree.Insert(100, make([]byte, limit+1)); err == nil {
+		t.Fatal("Insert accepted a value larger than a third of a page")
+	}
+
+	checkTree(t, tree, want)
+
+	if _, err := tree.Insert(100, make([]byte, limit)); err != nil {
+		t.Fatalf("Insert of a value of the largest size: %v", err)
+	}
+
+	want[100] = make([]byte, limit)
+	checkTree(t, tree, want)
+}
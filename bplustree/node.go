@@ -0,0 +1,209 @@
+package bplustree
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+const (
+	defaultPageSize = 4096
+	minPageSize     = 128
+	maxPageSize     = 1 << 16
+
+	metaPageID = 0
+
+	leafPage     = 1
+	internalPage = 2
+	freePage     = 3
+
+	// type, entry count and next page id
+	nodeHeaderSize = 1 + 2 + 8
+	// key and value length
+	leafEntryOverhead = 8 + 2
+	// key and child page id
+	internalEntrySize = 8 + 8
+)
+
+var metaMagic = []byte("BPT+")
+
+// node represents a decoded page, leaves hold keys and values and link to the next
+// leaf, internal nodes hold len(keys)+1 children where children[i] holds the keys
+// greater than or equal to keys[i-1] and less than keys[i]
+type node struct {
+	id       uint64
+	leaf     bool
+	keys     []int
+	values   [][]byte
+	children []uint64
+	next     uint64
+}
+
+// meta represents the content of the first page
+type meta struct {
+	pageSize uint32
+	root     uint64
+	size     uint64
+	height   uint32
+	freeHead uint64
+}
+
+// maxValueSize keeps at least three entries per leaf so splitting a leaf in half by bytes always fits
+func maxValueSize(pageSize int) int {
+	return (pageSize-nodeHeaderSize)/3 - leafEntryOverhead
+}
+
+func maxInternalKeys(pageSize int) int {
+	return (pageSize - nodeHeaderSize - 8) / internalEntrySize
+}
+
+// encodedSize returns the number of bytes the node takes in a page
+func (n *node) encodedSize() int {
+	if !n.leaf {
+		return nodeHeaderSize + 8 + len(n.keys)*internalEntrySize
+	}
+
+	size := nodeHeaderSize
+
+	for _, value := range n.values {
+		size += leafEntryOverhead + len(value)
+	}
+
+	return size
+}
+
+func (n *node) encode(pageSize int) []byte {
+	page := make([]byte, pageSize)
+
+	if n.leaf {
+		page[0] = leafPage
+	} else {
+		page[0] = internalPage
+	}
+
+	binary.LittleEndian.PutUint16(page[1:], uint16(len(n.keys)))
+	binary.LittleEndian.PutUint64(page[3:], n.next)
+	offset := nodeHeaderSize
+
+	if n.leaf {
+		for i, key := range n.keys {
+			binary.LittleEndian.PutUint64(page[offset:], uint64(key))
+			binary.LittleEndian.PutUint16(page[offset+8:], uint16(len(n.values[i])))
+			copy(page[offset+leafEntryOverhead:], n.values[i])
+			offset += leafEntryOverhead + len(n.values[i])
+		}
+
+		return page
+	}
+
+	binary.LittleEndian.PutUint64(page[offset:], n.children[0])
+	offset += 8
+
+	for i, key := range n.keys {
+		binary.LittleEndian.PutUint64(page[offset:], uint64(key))
+		binary.LittleEndian.PutUint64(page[offset+8:], n.children[i+1])
+		offset += internalEntrySize
+	}
+
+	return page
+}
+
+func decodeNode(id uint64, page []byte) (*node, error) {
+	if page[0] != leafPage && page[0] != internalPage {
+		return nil, errors.New("page is not a node")
+	}
+
+	n := &node{id: id, leaf: page[0] == leafPage}
+	count := int(binary.LittleEndian.Uint16(page[1:]))
+	n.next = binary.LittleEndian.Uint64(page[3:])
+	n.keys = make([]int, count)
+	offset := nodeHeaderSize
+
+	if n.leaf {
+		n.values = make([][]byte, count)
+
+		for i := 0; i < count; i++ {
+			if offset+leafEntryOverhead > len(page) {
+				return nil, errors.New("page is corrupt")
+			}
+
+			n.keys[i] = int(binary.LittleEndian.Uint64(page[offset:]))
+			length := int(binary.LittleEndian.Uint16(page[offset+8:]))
+			offset += leafEntryOverhead
+
+			if offset+length > len(page) {
+				return nil, errors.New("page is corrupt")
+			}
+
+			n.values[i] = append([]byte(nil), page[offset:offset+length]...)
+			offset += length
+		}
+
+		return n, nil
+	}
+
+	if nodeHeaderSize+8+count*internalEntrySize > len(page) {
+		return nil, errors.New("page is corrupt")
+	}
+
+	n.children = make([]uint64, count+1)
+	n.children[0] = binary.LittleEndian.Uint64(page[offset:])
+	offset += 8
+
+	for i := 0; i < count; i++ {
+		n.keys[i] = int(binary.LittleEndian.Uint64(page[offset:]))
+		n.children[i+1] = binary.LittleEndian.Uint64(page[offset+8:])
+		offset += internalEntrySize
+	}
+
+	return n, nil
+}
+
+func (m *meta) encode(pageSize int) []byte {
+	page := make([]byte, pageSize)
+
+	copy(page, metaMagic)
+	binary.LittleEndian.PutUint32(page[4:], m.pageSize)
+	binary.LittleEndian.PutUint64(page[8:], m.root)
+	binary.LittleEndian.PutUint64(page[16:], m.size)
+	binary.LittleEndian.PutUint32(page[24:], m.height)
+	binary.LittleEndian.PutUint64(page[28:], m.freeHead)
+	binary.LittleEndian.PutUint32(page[36:], crc32.ChecksumIEEE(page[:36]))
+
+	return page
+}
+
+func decodeMeta(page []byte) (*meta, error) {
+	if string(page[:4]) != string(metaMagic) {
+		return nil, errors.New("file is not a B+ tree")
+	}
+
+	if binary.LittleEndian.Uint32(page[36:]) != crc32.ChecksumIEEE(page[:36]) {
+		return nil, errors.New("meta page is corrupt")
+	}
+
+	return &meta{
+		pageSize: binary.LittleEndian.Uint32(page[4:]),
+		root:     binary.LittleEndian.Uint64(page[8:]),
+		size:     binary.LittleEndian.Uint64(page[16:]),
+		height:   binary.LittleEndian.Uint32(page[24:]),
+		freeHead: binary.LittleEndian.Uint64(page[28:]),
+	}, nil
+}
+
+// encodeFree marks a page as free, linking it to the next free page
+func encodeFree(next uint64, pageSize int) []byte {
+	page := make([]byte, pageSize)
+	page[0] = freePage
+	binary.LittleEndian.PutUint64(page[3:], next)
+
+	return page
+}
+
+func decodeFree(page []byte) (uint64, error) {
+	if page[0] != freePage {
+		return 0, errors.New("page is not free")
+	}
+
+	return binary.LittleEndian.Uint64(page[3:]), nil
+}
@@ -0,0 +1,359 @@
+package bplustree
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	cacheLimit    = 256
+	journalSuffix = "-journal"
+)
+
+var journalMagic = []byte("BPTJ")
+
+// pager represents a single file split into fixed size pages with a page cache, changes
+// are buffered until commit which makes them durable using a rollback journal: the
+// original content of every changed page is synced to the journal before the file is
+// overwritten, so a crash at any point can be undone when the file is opened again, the
+// directory is synced after the journal is created and removed so its entry is durable too
+type pager struct {
+	file        *os.File
+	journalPath string
+	pageSize    int
+	pageCount   uint64
+	cache       map[uint64][]byte
+	dirty       map[uint64]bool
+	originals   map[uint64][]byte
+	txPageCount uint64
+}
+
+// openPager opens or creates the file at path, rolling back an interrupted commit first,
+// an existing file keeps the page size stored in its meta page, a new one uses pageSize
+func openPager(path string, pageSize int) (*pager, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pager{
+		file:        file,
+		journalPath: path + journalSuffix,
+		cache:       make(map[uint64][]byte),
+		dirty:       make(map[uint64]bool),
+		originals:   make(map[uint64][]byte),
+	}
+
+	if err := p.open(pageSize); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *pager) open(pageSize int) error {
+	if err := p.recover(); err != nil {
+		return err
+	}
+
+	storedPageSize, err := p.storedPageSize()
+	if err != nil {
+		return err
+	}
+
+	if storedPageSize != 0 {
+		if pageSize != 0 && pageSize != storedPageSize {
+			return errors.New("page size does not match the file")
+		}
+
+		pageSize = storedPageSize
+	}
+
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	if pageSize < minPageSize || pageSize > maxPageSize {
+		return errors.New("page size out of range")
+	}
+
+	info, err := p.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	p.pageSize = pageSize
+	p.pageCount = uint64(info.Size()) / uint64(pageSize)
+	p.txPageCount = p.pageCount
+
+	return nil
+}
+
+// read returns the content of a page, the slice must not be modified, use write instead
+func (p *pager) read(id uint64) ([]byte, error) {
+	if data, ok := p.cache[id]; ok {
+		return data, nil
+	}
+
+	if id >= p.pageCount {
+		return nil, errors.New("page out of range")
+	}
+
+	data := make([]byte, p.pageSize)
+
+	if _, err := p.file.ReadAt(data, int64(id)*int64(p.pageSize)); err != nil {
+		return nil, err
+	}
+
+	p.cache[id] = data
+
+	return data, nil
+}
+
+// write replaces the content of a page in the cache, keeping its original for the journal
+func (p *pager) write(id uint64, data []byte) error {
+	if id >= p.pageCount {
+		return errors.New("page out of range")
+	}
+
+	if !p.dirty[id] && id < p.txPageCount {
+		original, err := p.read(id)
+		if err != nil {
+			return err
+		}
+
+		p.originals[id] = original
+	}
+
+	page := make([]byte, p.pageSize)
+	copy(page, data)
+
+	p.cache[id] = page
+	p.dirty[id] = true
+
+	return nil
+}
+
+// allocate grows the file by one zeroed page and returns its id
+func (p *pager) allocate() uint64 {
+	id := p.pageCount
+	p.pageCount++
+	p.cache[id] = make([]byte, p.pageSize)
+	p.dirty[id] = true
+
+	return id
+}
+
+// commit makes every write since the last commit durable, on failure every write is
+// discarded and the file is restored from the journal
+func (p *pager) commit() error {
+	if len(p.dirty) == 0 {
+		return nil
+	}
+
+	if err := p.flush(); err != nil {
+		p.rollback()
+		p.recover()
+		return err
+	}
+
+	p.dirty = make(map[uint64]bool)
+	p.originals = make(map[uint64][]byte)
+	p.txPageCount = p.pageCount
+	p.evict()
+
+	return nil
+}
+
+// flush journals the original pages, then overwrites them in the file
+func (p *pager) flush() error {
+	if err := p.writeJournal(); err != nil {
+		return err
+	}
+
+	for id := range p.dirty {
+		if _, err := p.file.WriteAt(p.cache[id], int64(id)*int64(p.pageSize)); err != nil {
+			return err
+		}
+	}
+
+	if err := p.file.Sync(); err != nil {
+		return err
+	}
+
+	return p.removeJournal()
+}
+
+// rollback discards every write since the last commit
+func (p *pager) rollback() {
+	for id := range p.dirty {
+		if original, ok := p.originals[id]; ok {
+			p.cache[id] = original
+		} else {
+			delete(p.cache, id)
+		}
+	}
+
+	p.dirty = make(map[uint64]bool)
+	p.originals = make(map[uint64][]byte)
+	p.pageCount = p.txPageCount
+}
+
+func (p *pager) close() error {
+	return p.file.Close()
+}
+
+// writeJournal syncs the original pages and page count with a trailing checksum,
+// a journal with a bad checksum was never completed so the file was never touched
+func (p *pager) writeJournal() error {
+	buf := make([]byte, 0, 20+len(p.originals)*(8+p.pageSize)+4)
+	buf = append(buf, journalMagic...)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(p.pageSize))
+	buf = binary.LittleEndian.AppendUint64(buf, p.txPageCount)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(p.originals)))
+
+	for id, original := range p.originals {
+		buf = binary.LittleEndian.AppendUint64(buf, id)
+		buf = append(buf, original...)
+	}
+
+	buf = binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf))
+
+	journal, err := os.OpenFile(p.journalPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := journal.Write(buf); err != nil {
+		journal.Close()
+		return err
+	}
+
+	if err := journal.Sync(); err != nil {
+		journal.Close()
+		return err
+	}
+
+	if err := journal.Close(); err != nil {
+		return err
+	}
+
+	return syncDir(p.journalPath)
+}
+
+// removeJournal deletes the journal once the file no longer needs it
+func (p *pager) removeJournal() error {
+	if err := os.Remove(p.journalPath); err != nil {
+		return err
+	}
+
+	return syncDir(p.journalPath)
+}
+
+// syncDir makes the creation or removal of the file at path durable by syncing its directory
+func syncDir(path string) error {
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	if err := dir.Sync(); err != nil {
+		dir.Close()
+		return err
+	}
+
+	return dir.Close()
+}
+
+// recover restores the pages saved in a complete journal left behind by an interrupted commit
+func (p *pager) recover() error {
+	journal, err := os.ReadFile(p.journalPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !validJournal(journal) {
+		return p.removeJournal()
+	}
+
+	pageSize := int(binary.LittleEndian.Uint32(journal[4:]))
+	pageCount := binary.LittleEndian.Uint64(journal[8:])
+	records := int(binary.LittleEndian.Uint32(journal[16:]))
+
+	for i := 0; i < records; i++ {
+		record := journal[20+i*(8+pageSize):]
+		id := binary.LittleEndian.Uint64(record)
+
+		if _, err := p.file.WriteAt(record[8:8+pageSize], int64(id)*int64(pageSize)); err != nil {
+			return err
+		}
+	}
+
+	if err := p.file.Truncate(int64(pageCount) * int64(pageSize)); err != nil {
+		return err
+	}
+
+	if err := p.file.Sync(); err != nil {
+		return err
+	}
+
+	return p.removeJournal()
+}
+
+func validJournal(journal []byte) bool {
+	if len(journal) < 24 || string(journal[:4]) != string(journalMagic) {
+		return false
+	}
+
+	pageSize := int(binary.LittleEndian.Uint32(journal[4:]))
+	records := int(binary.LittleEndian.Uint32(journal[16:]))
+
+	if pageSize == 0 || len(journal) != 20+records*(8+pageSize)+4 {
+		return false
+	}
+
+	checksum := binary.LittleEndian.Uint32(journal[len(journal)-4:])
+
+	return crc32.ChecksumIEEE(journal[:len(journal)-4]) == checksum
+}
+
+// evict drops clean pages once the cache grows past its limit
+func (p *pager) evict() {
+	for id := range p.cache {
+		if len(p.cache) <= cacheLimit {
+			return
+		}
+
+		if !p.dirty[id] {
+			delete(p.cache, id)
+		}
+	}
+}
+
+// storedPageSize reads the page size from the meta page, 0 for a new file
+func (p *pager) storedPageSize() (int, error) {
+	header := make([]byte, 8)
+
+	if _, err := p.file.ReadAt(header, 0); err != nil {
+		if err == io.EOF {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	if string(header[:4]) != string(metaMagic) {
+		return 0, errors.New("file is not a B+ tree")
+	}
+
+	return int(binary.LittleEndian.Uint32(header[4:])), nil
+}
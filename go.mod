@@ -1,3 +1,3 @@
 module github.com/seonicklaus/data-structures-go
 
-go 1.16
+go 1.23
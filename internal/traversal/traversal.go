@@ -0,0 +1,100 @@
+// Package traversal holds the PrintTree orders shared by the binary search tree variants
+package traversal
+
+import (
+	"errors"
+)
+
+// Node is implemented by the node pointers of a binary tree, the zero value is the empty tree
+type Node[N any] interface {
+	comparable
+	Value() int
+	Children() (left N, right N)
+}
+
+// PrintTree returns the values of the tree at root in the order named by one of "preorder",
+// "inorder", "postorder" or "levelorder"
+func PrintTree[N Node[N]](root N, order string) ([]int, error) {
+	var data []int
+
+	switch order {
+	case "preorder":
+		return preorder(root, data), nil
+	case "inorder":
+		return inorder(root, data), nil
+	case "postorder":
+		return postorder(root, data), nil
+	case "levelorder":
+		return levelorder(root), nil
+	default:
+		return nil, errors.New("order is invalid")
+	}
+}
+
+func preorder[N Node[N]](n N, data []int) []int {
+	var empty N
+
+	if n == empty {
+		return data
+	}
+
+	left, right := n.Children()
+	data = append(data, n.Value())
+	data = preorder(left, data)
+	return preorder(right, data)
+}
+
+func inorder[N Node[N]](n N, data []int) []int {
+	var empty N
+
+	if n == empty {
+		return data
+	}
+
+	left, right := n.Children()
+	data = inorder(left, data)
+	data = append(data, n.Value())
+	return inorder(right, data)
+}
+
+func postorder[N Node[N]](n N, data []int) []int {
+	var empty N
+
+	if n == empty {
+		return data
+	}
+
+	left, right := n.Children()
+	data = postorder(left, data)
+	data = postorder(right, data)
+	return append(data, n.Value())
+}
+
+func levelorder[N Node[N]](root N) []int {
+	var data []int
+	var empty N
+
+	if root == empty {
+		return data
+	}
+
+	queue := []N{root}
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		data = append(data, n.Value())
+
+		left, right := n.Children()
+
+		if left != empty {
+			queue = append(queue, left)
+		}
+
+		if right != empty {
+			queue = append(queue, right)
+		}
+	}
+
+	return data
+}
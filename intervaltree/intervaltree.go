@@ -0,0 +1,315 @@
+package intervaltree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Interval represents the half-open range [Start, End)
+type Interval struct {
+	Start int
+	End   int
+}
+
+// IsEmpty checks if the interval holds no point, which is when Start >= End
+func (i Interval) IsEmpty() bool {
+	return i.Start >= i.End
+}
+
+// Overlaps checks if two half-open intervals share at least one point, an empty interval overlaps nothing
+func (i Interval) Overlaps(other Interval) bool {
+	if i.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+
+	return i.Start < other.End && other.Start < i.End
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("[%d, %d)", i.Start, i.End)
+}
+
+// node represents an AVL Tree node keyed by interval start, it holds the largest
+// end point in its subtree so whole subtrees can be skipped while searching
+type node struct {
+	interval Interval
+	maxEnd   int
+	height   int
+	left     *node
+	right    *node
+}
+
+// IntervalTree represents a balanced Binary Search Tree of intervals ordered by start then end
+type IntervalTree struct {
+	root      *node
+	nodeCount uint
+}
+
+func (t *IntervalTree) Size() uint {
+	return t.nodeCount
+}
+
+func (t *IntervalTree) IsEmpty() bool {
+	return t.nodeCount == 0
+}
+
+func (t *IntervalTree) GetHeight() int {
+	return height(t.root)
+}
+
+// Check if interval is in tree, O(log n)
+func (t *IntervalTree) Contains(interval Interval) bool {
+	n := t.root
+
+	for n != nil {
+		cmp := compareTo(interval, n.interval)
+
+		if cmp == 0 {
+			return true
+		}
+
+		if cmp < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return false
+}
+
+// Insert interval into tree, returns false if it is already present, O(log n)
+func (t *IntervalTree) Insert(interval Interval) (bool, error) {
+	if interval.IsEmpty() {
+		return false, errors.New("interval is empty")
+	}
+
+	if t.Contains(interval) {
+		return false, nil
+	}
+
+	t.root = t.insert(t.root, interval)
+	t.nodeCount++
+
+	return true, nil
+}
+
+// Delete interval from tree, returns false if it is not present, O(log n)
+func (t *IntervalTree) Delete(interval Interval) bool {
+	if !t.Contains(interval) {
+		return false
+	}
+
+	t.root = t.delete(t.root, interval)
+	t.nodeCount--
+
+	return true
+}
+
+// Overlapping returns every interval overlapping query ordered by start, none for an empty query, O(log n + k)
+func (t *IntervalTree) Overlapping(query Interval) []Interval {
+	var result []Interval
+
+	if query.IsEmpty() {
+		return result
+	}
+
+	return t.overlapping(t.root, query, result)
+}
+
+// AnyOverlap returns one interval overlapping query, none for an empty query, O(log n)
+func (t *IntervalTree) AnyOverlap(query Interval) (Interval, bool) {
+	if query.IsEmpty() {
+		return Interval{}, false
+	}
+
+	n := t.root
+
+	for n != nil {
+		if n.interval.Overlaps(query) {
+			return n.interval, true
+		}
+
+		// If the left subtree reaches past the query start it either holds an overlap,
+		// or every interval in it starts after the query ends and so does the right subtree
+		if n.left != nil && n.left.maxEnd > query.Start {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return Interval{}, false
+}
+
+// Intervals returns every interval ordered by start then end, O(n)
+func (t *IntervalTree) Intervals() []Interval {
+	var result []Interval
+	return inorder(t.root, result)
+}
+
+func (t *IntervalTree) insert(n *node, interval Interval) *node {
+	if n == nil {
+		return &node{interval: interval, maxEnd: interval.End, height: 1}
+	}
+
+	if compareTo(interval, n.interval) < 0 {
+		n.left = t.insert(n.left, interval)
+	} else {
+		n.right = t.insert(n.right, interval)
+	}
+
+	return rebalance(n)
+}
+
+func (t *IntervalTree) delete(n *node, interval Interval) *node {
+	if n == nil {
+		return nil
+	}
+
+	cmp := compareTo(interval, n.interval)
+
+	if cmp < 0 {
+		n.left = t.delete(n.left, interval)
+	} else if cmp > 0 {
+		n.right = t.delete(n.right, interval)
+	} else {
+
+		if n.left == nil {
+			rightChild := n.right
+			n.right = nil
+			return rightChild
+		} else if n.right == nil {
+			leftChild := n.left
+			n.left = nil
+			return leftChild
+		} else {
+			smallestRight := n.right
+
+			for smallestRight.left != nil {
+				smallestRight = smallestRight.left
+			}
+
+			n.interval = smallestRight.interval
+			n.right = t.delete(n.right, smallestRight.interval)
+		}
+	}
+
+	return rebalance(n)
+}
+
+func (t *IntervalTree) overlapping(n *node, query Interval, result []Interval) []Interval {
+	if n == nil || n.maxEnd <= query.Start {
+		return result
+	}
+
+	result = t.overlapping(n.left, query, result)
+
+	// Everything to the right starts at or after this node, so stop once it starts past the query
+	if n.interval.Start >= query.End {
+		return result
+	}
+
+	if n.interval.Overlaps(query) {
+		result = append(result, n.interval)
+	}
+
+	return t.overlapping(n.right, query, result)
+}
+
+// rebalance restores the AVL invariant at n after one of its subtrees changed height by one
+func rebalance(n *node) *node {
+	update(n)
+	balance := height(n.left) - height(n.right)
+
+	if balance > 1 {
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+
+		return rotateRight(n)
+	}
+
+	if balance < -1 {
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+
+		return rotateLeft(n)
+	}
+
+	return n
+}
+
+func rotateLeft(n *node) *node {
+	r := n.right
+	n.right = r.left
+	r.left = n
+
+	update(n)
+	update(r)
+
+	return r
+}
+
+func rotateRight(n *node) *node {
+	l := n.left
+	n.left = l.right
+	l.right = n
+
+	update(n)
+	update(l)
+
+	return l
+}
+
+// update recomputes the height and max end of n from its children
+func update(n *node) {
+	n.height = max(height(n.left), height(n.right)) + 1
+	n.maxEnd = n.interval.End
+
+	if n.left != nil && n.left.maxEnd > n.maxEnd {
+		n.maxEnd = n.left.maxEnd
+	}
+
+	if n.right != nil && n.right.maxEnd > n.maxEnd {
+		n.maxEnd = n.right.maxEnd
+	}
+}
+
+func inorder(n *node, result []Interval) []Interval {
+	if n == nil {
+		return result
+	}
+
+	result = inorder(n.left, result)
+	result = append(result, n.interval)
+	return inorder(n.right, result)
+}
+
+func height(n *node) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+// compareTo orders intervals by start, then by end
+func compareTo(x, y Interval) int {
+	if x.Start > y.Start {
+		return 1
+	} else if x.Start < y.Start {
+		return -1
+	} else if x.End > y.End {
+		return 1
+	} else if x.End < y.End {
+		return -1
+	} else {
+		return 0
+	}
+}
+
+func NewTree() *IntervalTree {
+	return &IntervalTree{}
+}
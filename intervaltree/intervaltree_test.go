@@ -0,0 +1,200 @@
+package intervaltree
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestEmptyQueryOverlapsNothing(t *testing.T) {
+	tree := NewTree()
+
+	for _, interval := range []Interval{{3, 7}, {0, 10}, {5, 6}} {
+		if _, err := tree.Insert(interval); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, query := range []Interval{{5, 5}, {6, 4}, {0, 0}} {
+		if interval, ok := tree.AnyOverlap(query); ok {
+			t.Errorf("AnyOverlap(%v) = %v, true, want no overlap", query, interval)
+		}
+
+		if got := tree.Overlapping(query); len(got) != 0 {
+			t.Errorf("Overlapping(%v) = %v, want none", query, got)
+		}
+
+		if (Interval{3, 7}).Overlaps(query) || query.Overlaps(Interval{3, 7}) {
+			t.Errorf("%v overlaps [3, 7)", query)
+		}
+	}
+
+	if got := tree.Overlapping(Interval{5, 6}); len(got) != 3 {
+		t.Errorf("Overlapping([5, 6)) = %v, want all three intervals", got)
+	}
+}
+
+func TestInsertRejectsEmptyInterval(t *testing.T) {
+	tree := NewTree()
+
+	if _, err := tree.Insert(Interval{4, 4}); err == nil {
+		t.Fatal("Insert([4, 4)) returned no error")
+	}
+}
+
+// checkNode checks order, AVL balance, stored height and maxEnd of a subtree whose
+// intervals lie strictly between low and high, returns its height
+func checkNode(t *testing.T, n *node, low, high *Interval) int {
+	t.Helper()
+
+	if n == nil {
+		return 0
+	}
+
+	if (low != nil && compareTo(n.interval, *low) <= 0) || (high != nil && compareTo(n.interval, *high) >= 0) {
+		t.Fatalf("%v is out of order", n.interval)
+	}
+
+	leftHeight := checkNode(t, n.left, low, &n.interval)
+	rightHeight := checkNode(t, n.right, &n.interval, high)
+
+	if leftHeight-rightHeight > 1 || rightHeight-leftHeight > 1 {
+		t.Fatalf("%v has subtree heights %d and %d", n.interval, leftHeight, rightHeight)
+	}
+
+	if n.height != max(leftHeight, rightHeight)+1 {
+		t.Fatalf("%v stores height %d", n.interval, n.height)
+	}
+
+	maxEnd := n.interval.End
+
+	for _, child := range []*node{n.left, n.right} {
+		if child != nil {
+			maxEnd = max(maxEnd, child.maxEnd)
+		}
+	}
+
+	if n.maxEnd != maxEnd {
+		t.Fatalf("%v stores maxEnd %d, want %d", n.interval, n.maxEnd, maxEnd)
+	}
+
+	return n.height
+}
+
+// checkTree checks the tree holds exactly the intervals of set, and answers queries as a brute force scan does
+func checkTree(t *testing.T, r *rand.Rand, tree *IntervalTree, set map[Interval]bool) {
+	t.Helper()
+
+	checkNode(t, tree.root, nil, nil)
+	sorted := slices.SortedFunc(maps.Keys(set), compareTo)
+
+	if got := tree.Intervals(); !slices.Equal(got, sorted) || tree.Size() != uint(len(set)) {
+		t.Fatalf("tree holds %v with Size() %d, want %v", got, tree.Size(), sorted)
+	}
+
+	for i := 0; i < 10; i++ {
+		query := Interval{r.IntN(80) - 5, 0}
+		query.End = query.Start + r.IntN(12) - 1
+
+		var want []Interval
+
+		for _, interval := range sorted {
+			if interval.Overlaps(query) {
+				want = append(want, interval)
+			}
+		}
+
+		if got := tree.Overlapping(query); !slices.Equal(got, want) {
+			t.Fatalf("Overlapping(%v) = %v, want %v", query, got, want)
+		}
+
+		interval, ok := tree.AnyOverlap(query)
+
+		if ok != (len(want) > 0) || (ok && (!set[interval] || !interval.Overlaps(query))) {
+			t.Fatalf("AnyOverlap(%v) = %v, %t, want one of %v", query, interval, ok, want)
+		}
+	}
+}
+
+func TestRandomAgainstBruteForce(t *testing.T) {
+	r := rand.New(rand.NewPCG(6, 3))
+	tree := NewTree()
+	set := make(map[Interval]bool)
+	twoChildDeletes := 0
+
+	for step := 0; step < 3000; step++ {
+		start := r.IntN(60)
+		interval := Interval{start, start + 1 + r.IntN(15)}
+
+		// Delete an interval already in the tree most of the time
+		if r.IntN(3) == 0 {
+			if len(set) > 0 && r.IntN(4) != 0 {
+				held := slices.SortedFunc(maps.Keys(set), compareTo)
+				interval = held[r.IntN(len(held))]
+			}
+
+			if n := findNode(tree.root, interval); n != nil && n.left != nil && n.right != nil {
+				twoChildDeletes++
+			}
+
+			if tree.Delete(interval) != set[interval] {
+				t.Fatalf("step %d: Delete(%v) disagrees with the reference", step, interval)
+			}
+
+			delete(set, interval)
+		} else {
+			added, err := tree.Insert(interval)
+
+			if err != nil || added == set[interval] {
+				t.Fatalf("step %d: Insert(%v) = %t, %v", step, interval, added, err)
+			}
+
+			set[interval] = true
+		}
+
+		checkTree(t, r, tree, set)
+	}
+
+	if twoChildDeletes == 0 {
+		t.Fatal("no node with two children was deleted")
+	}
+}
+
+// Deleting the root replaces it with its successor and must refresh maxEnd up the path
+func TestDeleteNodeWithTwoChildren(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 1))
+	tree := NewTree()
+	set := make(map[Interval]bool)
+
+	for _, interval := range []Interval{{10, 12}, {5, 40}, {20, 22}, {3, 4}, {7, 8}, {15, 50}, {25, 26}} {
+		tree.Insert(interval)
+		set[interval] = true
+	}
+
+	for tree.root != nil && tree.root.left != nil && tree.root.right != nil {
+		root := tree.root.interval
+
+		if !tree.Delete(root) {
+			t.Fatalf("Delete(%v) = false", root)
+		}
+
+		delete(set, root)
+		checkTree(t, r, tree, set)
+	}
+}
+
+func findNode(n *node, interval Interval) *node {
+	for n != nil {
+		switch cmp := compareTo(interval, n.interval); {
+		case cmp == 0:
+			return n
+		case cmp < 0:
+			n = n.left
+		default:
+			n = n.right
+		}
+	}
+
+	return nil
+}
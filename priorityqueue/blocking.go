@@ -0,0 +1,243 @@
+package priorityqueue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrClosed is returned when putting into a closed queue, or taking from a closed queue that has been drained
+	ErrClosed = errors.New("priority queue is closed")
+	// ErrFull is returned by TryPut when the queue holds as many items as its capacity
+	ErrFull = errors.New("priority queue is full")
+	// ErrEmpty is returned by TryTake when the queue holds no items
+	ErrEmpty = errors.New("priority queue is empty")
+)
+
+// BlockingPriorityQueue represents Priority Queue data structure safe for concurrent use,
+// Take waits while the queue is empty and Put waits while it holds capacity items, every
+// waiter parks on its own channel, a Put wakes one waiting Take and a Take wakes one
+// waiting Put
+type BlockingPriorityQueue[T any] struct {
+	mu       sync.Mutex
+	queue    *PriorityQueue[T, struct{}]
+	capacity int
+	closed   bool
+	takers   waiters
+	putters  waiters
+}
+
+// waiters represents the goroutines parked on a Blocking Priority Queue in arrival order,
+// every channel has room for one signal so signalling never blocks
+type waiters []chan struct{}
+
+// Initialize an empty Blocking Priority Queue holding at most capacity items, 0 means no
+// bound, less reports whether a comes out before b, it may be nil when WithComparator is given
+func InitBlocking[T any](capacity uint, less func(a, b T) bool, opts ...Option[T]) *BlockingPriorityQueue[T] {
+	return &BlockingPriorityQueue[T]{
+		queue:    Init[T, struct{}](nil, less, nil, opts...),
+		capacity: int(capacity),
+	}
+}
+
+func (bq *BlockingPriorityQueue[T]) Size() int {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	return bq.queue.Size()
+}
+
+// Cap returns the capacity bound, 0 when unbounded
+func (bq *BlockingPriorityQueue[T]) Cap() int {
+	return bq.capacity
+}
+
+func (bq *BlockingPriorityQueue[T]) IsClosed() bool {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	return bq.closed
+}
+
+// Put adds item, waiting while the queue is full until there is room, ctx is done or the
+// queue is closed
+func (bq *BlockingPriorityQueue[T]) Put(ctx context.Context, item T) error {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	for {
+		err := bq.tryPut(item)
+		if err != ErrFull {
+			return err
+		}
+
+		if err := bq.wait(ctx, &bq.putters); err != nil {
+			return err
+		}
+	}
+}
+
+// Take removes the item that comes out first, waiting while the queue is empty until an
+// item is put, ctx is done or the queue is closed, items left in a closed queue are
+// still taken before ErrClosed is returned
+func (bq *BlockingPriorityQueue[T]) Take(ctx context.Context) (T, error) {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	for {
+		item, err := bq.tryTake()
+		if err != ErrEmpty {
+			return item, err
+		}
+
+		if err := bq.wait(ctx, &bq.takers); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+// TryPut adds item without waiting, returning ErrFull when there is no room
+func (bq *BlockingPriorityQueue[T]) TryPut(item T) error {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	return bq.tryPut(item)
+}
+
+// TryTake removes the item that comes out first without waiting, returning ErrEmpty when
+// there is none
+func (bq *BlockingPriorityQueue[T]) TryTake() (T, error) {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	return bq.tryTake()
+}
+
+// Close stops the queue from accepting items and wakes up every waiter, waiting Puts
+// return ErrClosed while Takes keep receiving the items left until the queue is drained
+func (bq *BlockingPriorityQueue[T]) Close() error {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	if bq.closed {
+		return ErrClosed
+	}
+
+	bq.closed = true
+	bq.takers.broadcast()
+	bq.putters.broadcast()
+
+	return nil
+}
+
+// Drain removes every item in the order they come out, it is typically called after
+// Close to hand the items nobody took over to the caller
+func (bq *BlockingPriorityQueue[T]) Drain() []T {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	items := make([]T, 0, bq.queue.Size())
+
+	for !bq.queue.IsEmpty() {
+		item, _ := bq.queue.Dequeue()
+		items = append(items, item)
+	}
+
+	for range items {
+		bq.putters.signal()
+	}
+
+	return items
+}
+
+// tryPut adds item if there is room and wakes up one waiting Take, the lock must be held
+func (bq *BlockingPriorityQueue[T]) tryPut(item T) error {
+	if bq.closed {
+		return ErrClosed
+	}
+
+	if bq.capacity > 0 && bq.queue.Size() >= bq.capacity {
+		return ErrFull
+	}
+
+	bq.queue.Enqueue(item)
+	bq.takers.signal()
+
+	return nil
+}
+
+// tryTake removes the item that comes out first if any and wakes up one waiting Put, the
+// lock must be held
+func (bq *BlockingPriorityQueue[T]) tryTake() (T, error) {
+	if bq.queue.IsEmpty() {
+		var zero T
+
+		if bq.closed {
+			return zero, ErrClosed
+		}
+
+		return zero, ErrEmpty
+	}
+
+	item, _ := bq.queue.Dequeue()
+	bq.putters.signal()
+
+	return item, nil
+}
+
+// wait parks the caller in queue until it is signalled or ctx is done, the lock must be
+// held and is released while parked, a caller that gives up after being signalled hands
+// the signal on to the next waiter so the wake up is not lost
+func (bq *BlockingPriorityQueue[T]) wait(ctx context.Context, queue *waiters) error {
+	ch := make(chan struct{}, 1)
+	*queue = append(*queue, ch)
+
+	bq.mu.Unlock()
+
+	select {
+	case <-ch:
+		bq.mu.Lock()
+		return nil
+	case <-ctx.Done():
+		bq.mu.Lock()
+	}
+
+	if !queue.remove(ch) {
+		queue.signal()
+	}
+
+	return ctx.Err()
+}
+
+// signal wakes up the waiter that arrived first, if any
+func (w *waiters) signal() {
+	if len(*w) == 0 {
+		return
+	}
+
+	(*w)[0] <- struct{}{}
+	(*w)[0] = nil
+	*w = (*w)[1:]
+}
+
+// broadcast wakes up every waiter
+func (w *waiters) broadcast() {
+	for len(*w) > 0 {
+		w.signal()
+	}
+}
+
+// remove drops ch, reporting false when it was signalled already
+func (w *waiters) remove(ch chan struct{}) bool {
+	for i, waiting := range *w {
+		if waiting == ch {
+			*w = slices.Delete(*w, i, i+1)
+			return true
+		}
+	}
+
+	return false
+}
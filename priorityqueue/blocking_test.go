@@ -0,0 +1,239 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// parked waits until count reports n waiters parked on bq
+func parked[T any](t *testing.T, bq *BlockingPriorityQueue[T], count func() int, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for {
+		bq.mu.Lock()
+		got := count()
+		bq.mu.Unlock()
+
+		if got == n {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("%d waiters parked, want %d", got, n)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBlockingProducersAndConsumers(t *testing.T) {
+	const producers, perProducer = 4, 2000
+
+	bq := InitBlocking(8, cmp.Less[int])
+	taken := make(chan int, producers*perProducer)
+
+	var consumers sync.WaitGroup
+
+	for c := 0; c < 6; c++ {
+		consumers.Add(1)
+
+		go func() {
+			defer consumers.Done()
+
+			for {
+				item, err := bq.Take(context.Background())
+				if err != nil {
+					if err != ErrClosed {
+						t.Error(err)
+					}
+
+					return
+				}
+
+				taken <- item
+			}
+		}()
+	}
+
+	var puts sync.WaitGroup
+
+	for p := 0; p < producers; p++ {
+		puts.Add(1)
+
+		go func() {
+			defer puts.Done()
+
+			for i := 0; i < perProducer; i++ {
+				if err := bq.Put(context.Background(), p*perProducer+i); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+
+	puts.Wait()
+	bq.Close()
+	consumers.Wait()
+	close(taken)
+
+	seen := make(map[int]bool)
+
+	for item := range taken {
+		if seen[item] {
+			t.Fatalf("item %d taken twice", item)
+		}
+
+		seen[item] = true
+	}
+
+	if len(seen) != producers*perProducer {
+		t.Fatalf("%d items taken, want %d", len(seen), producers*perProducer)
+	}
+}
+
+func TestBlockingPutWakesOneTaker(t *testing.T) {
+	bq := InitBlocking(0, cmp.Less[int])
+	taken := make(chan int, 3)
+
+	for i := 0; i < 3; i++ {
+		go func() {
+			item, err := bq.Take(context.Background())
+			if err != nil {
+				return
+			}
+
+			taken <- item
+		}()
+	}
+
+	parked(t, bq, func() int { return len(bq.takers) }, 3)
+
+	if err := bq.TryPut(1); err != nil {
+		t.Fatal(err)
+	}
+
+	if item := <-taken; item != 1 {
+		t.Fatalf("Take() = %d, want 1", item)
+	}
+
+	// The other takers stay parked instead of waking up to find the queue empty
+	parked(t, bq, func() int { return len(bq.takers) }, 2)
+	bq.Close()
+}
+
+func TestBlockingTakeWakesOnePutter(t *testing.T) {
+	bq := InitBlocking(1, cmp.Less[int])
+
+	if err := bq.TryPut(0); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 3)
+
+	for i := 1; i <= 3; i++ {
+		go func() {
+			done <- bq.Put(context.Background(), i)
+		}()
+	}
+
+	parked(t, bq, func() int { return len(bq.putters) }, 3)
+
+	if item, err := bq.TryTake(); err != nil || item != 0 {
+		t.Fatalf("TryTake() = %d, %v", item, err)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	parked(t, bq, func() int { return len(bq.putters) }, 2)
+
+	if err := bq.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != ErrClosed {
+			t.Fatalf("Put on closed queue returned %v, want ErrClosed", err)
+		}
+	}
+
+	if items := bq.Drain(); len(items) != 1 {
+		t.Fatalf("Drain() = %v, want one item", items)
+	}
+}
+
+// A taker whose ctx is done before it gets the lock back may have been signalled meanwhile,
+// it hands the signal on so the item is not left behind with another taker parked
+func TestBlockingCancelledTakerHandsSignalOn(t *testing.T) {
+	bq := InitBlocking(0, cmp.Less[int])
+	ctx, cancel := context.WithCancel(context.Background())
+
+	first := make(chan error, 1)
+
+	go func() {
+		_, err := bq.Take(ctx)
+		first <- err
+	}()
+
+	parked(t, bq, func() int { return len(bq.takers) }, 1)
+
+	second := make(chan int, 1)
+
+	go func() {
+		item, err := bq.Take(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+
+		second <- item
+	}()
+
+	parked(t, bq, func() int { return len(bq.takers) }, 2)
+
+	// The first taker wakes up on ctx, then is signalled before it can take the lock
+	bq.mu.Lock()
+	cancel()
+	bq.tryPut(1)
+	bq.mu.Unlock()
+
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Take with cancelled ctx returned %v, want Canceled", err)
+	}
+
+	select {
+	case item := <-second:
+		if item != 1 {
+			t.Fatalf("Take() = %d, want 1", item)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the item was left in the queue while a taker waited")
+	}
+}
+
+func TestBlockingContextDone(t *testing.T) {
+	bq := InitBlocking(1, cmp.Less[int])
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := bq.Take(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Take on empty queue returned %v, want DeadlineExceeded", err)
+	}
+
+	bq.TryPut(1)
+
+	if err := bq.Put(ctx, 2); err != context.DeadlineExceeded {
+		t.Fatalf("Put on full queue returned %v, want DeadlineExceeded", err)
+	}
+
+	if len(bq.takers) != 0 || len(bq.putters) != 0 {
+		t.Fatalf("%d takers and %d putters left parked", len(bq.takers), len(bq.putters))
+	}
+}
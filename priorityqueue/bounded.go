@@ -0,0 +1,159 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"slices"
+)
+
+// boundedItem represents an item of a Bounded Heap with its insertion sequence, which is
+// also its ID in the underlying Priority Queue
+type boundedItem[T any] struct {
+	value T
+	seq   uint64
+}
+
+// BoundedHeap represents a heap that retains only the best limit items, the ones that
+// come out first under less, the underlying Priority Queue keeps the worst item on top
+// so it can be evicted in O(log k), among equal items the earliest enqueued are retained
+type BoundedHeap[T any] struct {
+	queue   *PriorityQueue[boundedItem[T], uint64]
+	lessFn  func(a, b T) bool
+	limit   int
+	nextSeq uint64
+}
+
+// Initialize an empty Bounded Heap retaining at most limit items, less reports whether a
+// is better than b, it may be nil when WithComparator is given, WithMaxHeap retains the
+// largest items instead of the smallest
+func InitBounded[T any](limit uint, less func(a, b T) bool, opts ...Option[T]) *BoundedHeap[T] {
+	o := buildOptions(less, opts)
+
+	bh := &BoundedHeap[T]{
+		lessFn: o.less,
+		limit:  int(limit),
+	}
+
+	// worse reports whether a is evicted before b, later items first among equal ones
+	worse := func(a, b boundedItem[T]) bool {
+		if bh.lessFn(b.value, a.value) {
+			return true
+		}
+
+		return !bh.lessFn(a.value, b.value) && a.seq > b.seq
+	}
+
+	bh.queue = Init(nil, worse, func(item boundedItem[T]) uint64 {
+		return item.seq
+	}, WithArity[boundedItem[T]](o.arity))
+
+	return bh
+}
+
+func (bh *BoundedHeap[T]) Size() int {
+	return bh.queue.Size()
+}
+
+func (bh *BoundedHeap[T]) IsEmpty() bool {
+	return bh.queue.IsEmpty()
+}
+
+// Limit returns the number of items retained at most
+func (bh *BoundedHeap[T]) Limit() int {
+	return bh.limit
+}
+
+// Enqueue adds item, evicting the worst item when more than limit items would be retained,
+// it returns the evicted item, which is item itself when it is not better than every
+// retained item, O(log k)
+func (bh *BoundedHeap[T]) Enqueue(item T) (T, bool) {
+	if bh.queue.Size() < bh.limit {
+		bh.queue.Enqueue(boundedItem[T]{value: item, seq: bh.nextSeq})
+		bh.nextSeq++
+
+		var zero T
+		return zero, false
+	}
+
+	if bh.limit == 0 {
+		return item, true
+	}
+
+	worst, _ := bh.queue.Peek()
+
+	if !bh.lessFn(item, worst.value) {
+		return item, true
+	}
+
+	bh.queue.Dequeue()
+	bh.queue.Enqueue(boundedItem[T]{value: item, seq: bh.nextSeq})
+	bh.nextSeq++
+
+	return worst.value, true
+}
+
+// Worst returns the retained item that would be evicted next, O(1)
+func (bh *BoundedHeap[T]) Worst() (T, error) {
+	worst, err := bh.queue.Peek()
+	if err != nil {
+		var zero T
+		return zero, errors.New("bounded heap is empty")
+	}
+
+	return worst.value, nil
+}
+
+// Items returns the retained items, best first, O(k log k)
+func (bh *BoundedHeap[T]) Items() []T {
+	items := slices.Clone(bh.queue.heap)
+
+	slices.SortFunc(items, func(a, b boundedItem[T]) int {
+		if bh.lessFn(a.value, b.value) {
+			return -1
+		}
+
+		if bh.lessFn(b.value, a.value) {
+			return 1
+		}
+
+		return cmp.Compare(a.seq, b.seq)
+	})
+
+	values := make([]T, len(items))
+
+	for i, item := range items {
+		values[i] = item.value
+	}
+
+	return values
+}
+
+func (bh *BoundedHeap[T]) Clear() {
+	bh.queue.Clear()
+	bh.nextSeq = 0
+}
+
+// TopK returns the best k items of seq, best first, less reports whether a is better than
+// b, equal items keep the order of seq, O(n log k) time and O(k) space
+func TopK[T any](seq iter.Seq[T], k int, less func(a, b T) bool) []T {
+	bh := InitBounded(uint(max(k, 0)), less)
+
+	for item := range seq {
+		bh.Enqueue(item)
+	}
+
+	return bh.Items()
+}
+
+// NSmallest returns the n smallest items of seq in ascending order
+func NSmallest[T cmp.Ordered](seq iter.Seq[T], n int) []T {
+	return TopK(seq, n, cmp.Less[T])
+}
+
+// NLargest returns the n largest items of seq in descending order
+func NLargest[T cmp.Ordered](seq iter.Seq[T], n int) []T {
+	return TopK(seq, n, func(a, b T) bool {
+		return cmp.Less(b, a)
+	})
+}
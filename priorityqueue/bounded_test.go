@@ -0,0 +1,135 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestBoundedHeapEviction(t *testing.T) {
+	bh := InitBounded(3, cmp.Less[int])
+
+	steps := []struct {
+		item    int
+		evicted int
+		ok      bool
+	}{
+		{5, 0, false},
+		{1, 0, false},
+		{4, 0, false},
+		{3, 5, true},
+		{9, 9, true},
+		{4, 4, true},
+		{2, 4, true},
+	}
+
+	for _, step := range steps {
+		if evicted, ok := bh.Enqueue(step.item); evicted != step.evicted || ok != step.ok {
+			t.Fatalf("Enqueue(%d) = %d, %t, want %d, %t", step.item, evicted, ok, step.evicted, step.ok)
+		}
+	}
+
+	if worst, err := bh.Worst(); err != nil || worst != 3 {
+		t.Errorf("Worst() = %d, %v, want 3", worst, err)
+	}
+
+	if got := bh.Items(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Items() = %v, want [1 2 3]", got)
+	}
+
+	if bh.Size() != 3 || bh.Limit() != 3 {
+		t.Errorf("Size() = %d, Limit() = %d, want 3 and 3", bh.Size(), bh.Limit())
+	}
+}
+
+func TestBoundedHeapZeroLimit(t *testing.T) {
+	bh := InitBounded(0, cmp.Less[int])
+
+	if evicted, ok := bh.Enqueue(7); evicted != 7 || !ok {
+		t.Errorf("Enqueue(7) = %d, %t, want 7, true", evicted, ok)
+	}
+
+	if !bh.IsEmpty() || len(bh.Items()) != 0 {
+		t.Errorf("heap with limit 0 retains %v", bh.Items())
+	}
+
+	if _, err := bh.Worst(); err == nil {
+		t.Error("Worst on empty heap returned no error")
+	}
+}
+
+// Among equal items the earliest enqueued are retained, so a later tie is evicted on arrival
+func TestBoundedHeapTiesKeepEarliest(t *testing.T) {
+	bh := InitBounded(2, taskLess)
+
+	bh.Enqueue(task{1, 0})
+	bh.Enqueue(task{1, 1})
+
+	if evicted, ok := bh.Enqueue(task{1, 2}); evicted != (task{1, 2}) || !ok {
+		t.Errorf("Enqueue of a tie evicted %v, %t, want the tie itself", evicted, ok)
+	}
+
+	if evicted, _ := bh.Enqueue(task{0, 3}); evicted != (task{1, 1}) {
+		t.Errorf("Enqueue of a better item evicted %v, want the latest tie", evicted)
+	}
+
+	if got, want := bh.Items(), []task{{0, 3}, {1, 0}}; !slices.Equal(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+}
+
+func TestBoundedHeapWithMaxHeap(t *testing.T) {
+	bh := InitBounded(3, cmp.Less[int], WithMaxHeap[int]())
+
+	for _, v := range []int{4, 8, 1, 9, 3, 7} {
+		bh.Enqueue(v)
+	}
+
+	if got := bh.Items(); !slices.Equal(got, []int{9, 8, 7}) {
+		t.Errorf("Items() = %v, want [9 8 7]", got)
+	}
+
+	if worst, _ := bh.Worst(); worst != 7 {
+		t.Errorf("Worst() = %d, want 7", worst)
+	}
+}
+
+// TopK matches a stable sort of the input cut to k, for k out of range on both sides
+func TestTopK(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 8))
+
+	for round := 0; round < 20; round++ {
+		items := make([]task, r.IntN(50))
+
+		for i := range items {
+			items[i] = task{priority: r.IntN(10), label: i}
+		}
+
+		sorted := fifoOrder(items)
+
+		for _, k := range []int{-1, 0, 1, 5, len(items), len(items) + 5} {
+			want := sorted[:max(min(k, len(items)), 0)]
+
+			if got := TopK(slices.Values(items), k, taskLess); !slices.Equal(got, want) {
+				t.Fatalf("TopK(k = %d) = %v, want %v", k, got, want)
+			}
+		}
+	}
+}
+
+func TestNSmallestAndNLargest(t *testing.T) {
+	items := []int{5, 2, 8, 2, 9, 1, 5}
+
+	if got := NSmallest(slices.Values(items), 3); !slices.Equal(got, []int{1, 2, 2}) {
+		t.Errorf("NSmallest = %v, want [1 2 2]", got)
+	}
+
+	if got := NLargest(slices.Values(items), 3); !slices.Equal(got, []int{9, 8, 5}) {
+		t.Errorf("NLargest = %v, want [9 8 5]", got)
+	}
+
+	if got := NLargest(slices.Values(items), 10); !slices.Equal(got, []int{9, 8, 5, 5, 2, 2, 1}) {
+		t.Errorf("NLargest beyond the input = %v", got)
+	}
+}
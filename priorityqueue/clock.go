@@ -0,0 +1,112 @@
+package priorityqueue
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock tells the time to a Delay Queue, so tests can replace the system clock with a ManualClock
+type Clock interface {
+	Now() time.Time
+	// Until returns a channel that receives the time once the clock reaches at, right away
+	// when it already has, and a stop function that releases the channel when the caller
+	// stops waiting
+	Until(at time.Time) (<-chan time.Time, func())
+}
+
+// systemClock represents the Clock backed by package time
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Until(at time.Time) (<-chan time.Time, func()) {
+	timer := time.NewTimer(time.Until(at))
+
+	return timer.C, func() {
+		timer.Stop()
+	}
+}
+
+// SystemClock returns the Clock backed by the system time
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// ManualClock represents a Clock that only moves when Advance is called, it is safe for concurrent use
+type ManualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*manualWaiter
+}
+
+// manualWaiter represents a channel returned by Until waiting for the clock to reach at
+type manualWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// Initialize a Manual Clock set to now
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Until returns a channel that receives the time once the clock is advanced to at, the
+// stop function drops it from the waiters if it has not fired
+func (c *ManualClock) Until(at time.Time) (<-chan time.Time, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+
+	if !at.After(c.now) {
+		ch <- c.now
+		return ch, func() {}
+	}
+
+	waiter := &manualWaiter{at: at, ch: ch}
+	c.waiters = append(c.waiters, waiter)
+
+	return ch, func() {
+		c.stop(waiter)
+	}
+}
+
+// Advance moves the clock forward by d, firing every channel whose time has come
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	waiting := c.waiters[:0]
+
+	for _, waiter := range c.waiters {
+		if waiter.at.After(c.now) {
+			waiting = append(waiting, waiter)
+		} else {
+			waiter.ch <- c.now
+		}
+	}
+
+	clear(c.waiters[len(waiting):])
+	c.waiters = waiting
+}
+
+// stop drops waiter, which may have fired already
+func (c *ManualClock) stop(waiter *manualWaiter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if i := slices.Index(c.waiters, waiter); i >= 0 {
+		c.waiters = slices.Delete(c.waiters, i, i+1)
+	}
+}
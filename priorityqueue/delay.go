@@ -0,0 +1,175 @@
+package priorityqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNotReady is returned by TryTake when no item has reached its ready time
+var ErrNotReady = errors.New("no item is ready")
+
+// DelayQueue represents a queue whose items become available at a scheduled time, an
+// Indexed Priority Queue orders them by ready time, items ready at the same time come
+// out in the order they were scheduled, it is safe for concurrent use
+type DelayQueue[T any] struct {
+	mu      sync.Mutex
+	queue   *IndexedPriorityQueue[T, time.Time]
+	clock   Clock
+	changed chan struct{}
+}
+
+// Initialize an empty Delay Queue reading the time from clock, nil means the system clock
+func InitDelay[T any](clock Clock) *DelayQueue[T] {
+	if clock == nil {
+		clock = SystemClock()
+	}
+
+	return &DelayQueue[T]{
+		queue: InitIndexed[T](func(a, b time.Time) bool {
+			return a.Before(b)
+		}, WithStableOrder[time.Time]()),
+		clock:   clock,
+		changed: make(chan struct{}),
+	}
+}
+
+func (dq *DelayQueue[T]) Size() int {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	return dq.queue.Size()
+}
+
+// Schedule adds item to become available at, returning the handle used by Cancel and Reschedule
+func (dq *DelayQueue[T]) Schedule(item T, at time.Time) Handle {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	head, headAt := dq.head()
+	handle := dq.queue.Enqueue(item, at)
+	dq.notifyIfHeadMoved(head, headAt)
+
+	return handle
+}
+
+// Cancel removes the item referred to by handle before it is taken
+func (dq *DelayQueue[T]) Cancel(handle Handle) (T, error) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	head, headAt := dq.head()
+
+	item, _, err := dq.queue.Remove(handle)
+	if err != nil {
+		return item, err
+	}
+
+	dq.notifyIfHeadMoved(head, headAt)
+
+	return item, nil
+}
+
+// Reschedule changes the time the item referred to by handle becomes available at
+func (dq *DelayQueue[T]) Reschedule(handle Handle, at time.Time) error {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	head, headAt := dq.head()
+
+	if err := dq.queue.UpdatePriority(handle, at); err != nil {
+		return err
+	}
+
+	dq.notifyIfHeadMoved(head, headAt)
+
+	return nil
+}
+
+// Take removes the item that becomes available first, waiting until its time has come or ctx is done
+func (dq *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		dq.mu.Lock()
+		item, at, err := dq.tryTake()
+		changed := dq.changed
+		dq.mu.Unlock()
+
+		if err == nil {
+			return item, nil
+		}
+
+		// Wake up on the ready time of the head, or on a change that may move it, the ready
+		// time is absolute so the clock moving past it meanwhile fires right away
+		var ready <-chan time.Time
+		stop := func() {}
+
+		if err == ErrNotReady {
+			ready, stop = dq.clock.Until(at)
+		}
+
+		select {
+		case <-ready:
+		case <-changed:
+		case <-ctx.Done():
+			stop()
+
+			var zero T
+			return zero, ctx.Err()
+		}
+
+		stop()
+	}
+}
+
+// TryTake removes the item that becomes available first without waiting, returning
+// ErrEmpty when there is none and ErrNotReady when its time has not come yet
+func (dq *DelayQueue[T]) TryTake() (T, error) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	item, _, err := dq.tryTake()
+
+	return item, err
+}
+
+// tryTake removes the head if its time has come, otherwise returns the time it becomes
+// available at, the lock must be held
+func (dq *DelayQueue[T]) tryTake() (T, time.Time, error) {
+	var zero T
+
+	_, at, err := dq.queue.Peek()
+	if err != nil {
+		return zero, time.Time{}, ErrEmpty
+	}
+
+	if at.After(dq.clock.Now()) {
+		return zero, at, ErrNotReady
+	}
+
+	// Waiters are not woken, each one waits for the ready time of the head it saw and
+	// changes to the head wake it, so it is already awake once that head can be taken
+	item, _, _ := dq.queue.Dequeue()
+
+	return item, at, nil
+}
+
+// head returns the handle and ready time of the head, a zero handle when the queue is
+// empty, the lock must be held
+func (dq *DelayQueue[T]) head() (Handle, time.Time) {
+	handle, _ := dq.queue.PeekHandle()
+	_, at, _ := dq.queue.Peek()
+
+	return handle, at
+}
+
+// notifyIfHeadMoved wakes up every waiter when the head is no longer handle ready at at,
+// changes behind the head leave the waiters asleep, the lock must be held
+func (dq *DelayQueue[T]) notifyIfHeadMoved(handle Handle, at time.Time) {
+	if head, headAt := dq.head(); head == handle && headAt.Equal(at) {
+		return
+	}
+
+	close(dq.changed)
+	dq.changed = make(chan struct{})
+}
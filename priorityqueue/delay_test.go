@@ -0,0 +1,261 @@
+package priorityqueue
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// advancingClock moves a ManualClock past every deadline just before a wait is registered,
+// as if Advance ran between Take reading the head and waiting for it
+type advancingClock struct {
+	*ManualClock
+}
+
+func (c advancingClock) Until(at time.Time) (<-chan time.Time, func()) {
+	c.Advance(at.Sub(c.Now()))
+
+	return c.ManualClock.Until(at)
+}
+
+// countingClock counts the waits registered on a ManualClock, a waiting Take registers
+// one every time it wakes up and finds nothing ready
+type countingClock struct {
+	*ManualClock
+	waits *atomic.Int64
+}
+
+func (c countingClock) Until(at time.Time) (<-chan time.Time, func()) {
+	c.waits.Add(1)
+
+	return c.ManualClock.Until(at)
+}
+
+// waiting returns the number of channels still registered on c
+func waiting(c *ManualClock) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.waiters)
+}
+
+func TestDelayOrder(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	dq := InitDelay[string](clock)
+
+	dq.Schedule("b", time.Unix(20, 0))
+	a := dq.Schedule("a", time.Unix(10, 0))
+	x := dq.Schedule("x", time.Unix(5, 0))
+	dq.Schedule("c", time.Unix(20, 0))
+
+	if _, err := dq.TryTake(); err != ErrNotReady {
+		t.Fatalf("TryTake() returned %v, want ErrNotReady", err)
+	}
+
+	if item, err := dq.Cancel(x); err != nil || item != "x" {
+		t.Fatalf("Cancel() = %q, %v", item, err)
+	}
+
+	if err := dq.Reschedule(a, time.Unix(30, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(30 * time.Second)
+
+	for _, want := range []string{"b", "c", "a"} {
+		if item, err := dq.TryTake(); err != nil || item != want {
+			t.Fatalf("TryTake() = %q, %v, want %q", item, err, want)
+		}
+	}
+
+	if _, err := dq.TryTake(); err != ErrEmpty {
+		t.Fatalf("TryTake() returned %v, want ErrEmpty", err)
+	}
+}
+
+func TestDelayTakeWaitsForAdvance(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	dq := InitDelay[int](clock)
+	dq.Schedule(1, time.Unix(10, 0))
+
+	taken := make(chan int, 1)
+
+	go func() {
+		item, err := dq.Take(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+
+		taken <- item
+	}()
+
+	for waiting(clock) != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	clock.Advance(9 * time.Second)
+
+	select {
+	case item := <-taken:
+		t.Fatalf("Take() = %d before its ready time", item)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(time.Second)
+
+	if item := <-taken; item != 1 {
+		t.Fatalf("Take() = %d, want 1", item)
+	}
+}
+
+func TestDelayAdvanceBeforeWaitDoesNotHang(t *testing.T) {
+	clock := advancingClock{NewManualClock(time.Unix(0, 0))}
+	dq := InitDelay[int](clock)
+	dq.Schedule(1, time.Unix(10, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if item, err := dq.Take(ctx); err != nil || item != 1 {
+		t.Fatalf("Take() = %d, %v, want 1", item, err)
+	}
+}
+
+// Take registers a new wait every time a change wakes it, the previous one must not linger
+func TestDelayTakeReleasesWaiters(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	dq := InitDelay[int](clock)
+	handle := dq.Schedule(1, time.Unix(1000, 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := dq.Take(ctx)
+		done <- err
+	}()
+
+	for i := 0; i < 100; i++ {
+		if err := dq.Reschedule(handle, time.Unix(int64(2000+i), 0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if n := waiting(clock); n > 1 {
+		t.Fatalf("%d waiters registered for one Take", n)
+	}
+
+	cancel()
+
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Take() returned %v, want Canceled", err)
+	}
+
+	if n := waiting(clock); n != 0 {
+		t.Fatalf("%d waiters left after Take returned", n)
+	}
+}
+
+// Only changes that move the head wake the waiting takers, and taking an item wakes nobody
+func TestDelayWakesTakersOnlyWhenHeadMoves(t *testing.T) {
+	const takers = 3
+
+	clock := countingClock{NewManualClock(time.Unix(0, 0)), &atomic.Int64{}}
+	dq := InitDelay[int](clock)
+	head := dq.Schedule(0, time.Unix(100, 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	taken := make(chan int, takers)
+
+	for i := 0; i < takers; i++ {
+		go func() {
+			if item, err := dq.Take(ctx); err == nil {
+				taken <- item
+			}
+		}()
+	}
+
+	// settled waits for every taker still running to wait again, then returns the waits registered so far
+	settled := func(want int) int64 {
+		t.Helper()
+
+		for waiting(clock.ManualClock) != want {
+			time.Sleep(time.Millisecond)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		return clock.waits.Load()
+	}
+
+	waits := settled(takers)
+
+	behind := dq.Schedule(1, time.Unix(200, 0))
+	dq.Schedule(2, time.Unix(100, 0))
+
+	if err := dq.Reschedule(behind, time.Unix(300, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := dq.Cancel(behind); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := settled(takers); got != waits {
+		t.Fatalf("changes behind the head woke the takers %d times", got-waits)
+	}
+
+	if err := dq.Reschedule(head, time.Unix(50, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := settled(takers); got != waits+takers {
+		t.Fatalf("moving the head woke the takers %d times, want %d", got-waits, takers)
+	}
+
+	// Two takers get the ready items and the third waits for the later one, woken by
+	// its clock alone since taking an item leaves the head it saw in place
+	later := dq.Schedule(3, time.Unix(1000, 0))
+	waits = settled(takers)
+	clock.Advance(100 * time.Second)
+
+	for i := 0; i < 2; i++ {
+		<-taken
+	}
+
+	if got := settled(1); got != waits+1 {
+		t.Fatalf("taking two items registered %d waits, want 1", got-waits)
+	}
+
+	if _, err := dq.Cancel(later); err != nil {
+		t.Fatal(err)
+	}
+
+	waits = settled(0)
+	dq.Schedule(4, time.Unix(500, 0))
+
+	if got := settled(1); got != waits+1 {
+		t.Fatalf("scheduling into an empty queue registered %d waits, want 1", got-waits)
+	}
+}
+
+func TestSystemClockUntil(t *testing.T) {
+	clock := SystemClock()
+
+	ready, stop := clock.Until(clock.Now().Add(-time.Second))
+	defer stop()
+
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Until a past time did not fire")
+	}
+
+	_, stop = clock.Until(clock.Now().Add(time.Hour))
+	stop()
+}
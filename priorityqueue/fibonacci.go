@@ -0,0 +1,309 @@
+package priorityqueue
+
+import (
+	"errors"
+)
+
+// FibonacciNode represents an element of a Fibonacci Heap, it is returned by Enqueue so
+// its value can be changed with DecreaseKey, siblings form a circular doubly linked list
+type FibonacciNode[T any] struct {
+	value   T
+	seq     uint64
+	parent  *FibonacciNode[T]
+	child   *FibonacciNode[T]
+	left    *FibonacciNode[T]
+	right   *FibonacciNode[T]
+	degree  int
+	marked  bool
+	removed bool
+}
+
+// FibonacciHeap represents a list of heap ordered trees, Enqueue and Meld only splice
+// root lists in O(1), Dequeue consolidates roots of equal degree in O(log n) amortized,
+// DecreaseKey cuts the node out of its parent in O(1) amortized
+type FibonacciHeap[T any] struct {
+	first  *FibonacciNode[T]
+	size   int
+	lessFn func(a, b T) bool
+	stable bool
+}
+
+// Initialize an empty Fibonacci Heap, less reports whether a comes out before b, it may
+// be nil when WithComparator is given
+func InitFibonacci[T any](less func(a, b T) bool, opts ...Option[T]) *FibonacciHeap[T] {
+	o := buildOptions(less, opts)
+
+	return &FibonacciHeap[T]{lessFn: o.less, stable: o.stable}
+}
+
+// Value returns the value held by the node
+func (n *FibonacciNode[T]) Value() T {
+	return n.value
+}
+
+func (fh *FibonacciHeap[T]) Size() int {
+	return fh.size
+}
+
+func (fh *FibonacciHeap[T]) IsEmpty() bool {
+	return fh.size == 0
+}
+
+// Add element into Heap and return its node, O(1)
+func (fh *FibonacciHeap[T]) Enqueue(element T) *FibonacciNode[T] {
+	n := &FibonacciNode[T]{value: element, seq: meldSequence.Add(1)}
+	n.left = n
+	n.right = n
+
+	fh.addRoot(n)
+	fh.size++
+
+	return n
+}
+
+func (fh *FibonacciHeap[T]) Peek() (T, error) {
+	if fh.IsEmpty() {
+		var zero T
+		return zero, errors.New("priority queue is empty")
+	}
+
+	return fh.first.value, nil
+}
+
+// Dequeue removes the element that comes out first, O(log n) amortized
+func (fh *FibonacciHeap[T]) Dequeue() (T, error) {
+	if fh.IsEmpty() {
+		var zero T
+		return zero, errors.New("priority queue is empty")
+	}
+
+	removed := fh.first
+
+	// Every child of the removed root becomes a root
+	for removed.child != nil {
+		child := removed.child
+		fh.unlink(child)
+
+		if child == child.right {
+			removed.child = nil
+		} else {
+			removed.child = child.right
+		}
+
+		child.left = child
+		child.right = child
+		child.parent = nil
+		child.marked = false
+		fh.splice(removed, child)
+	}
+
+	if removed == removed.right {
+		fh.first = nil
+	} else {
+		fh.first = removed.right
+		fh.unlink(removed)
+		fh.consolidate()
+	}
+
+	fh.size--
+	removed.left = nil
+	removed.right = nil
+	removed.removed = true
+
+	return removed.value, nil
+}
+
+// DecreaseKey replaces the value of a node of this heap with one that does not come out
+// after it, O(1) amortized
+func (fh *FibonacciHeap[T]) DecreaseKey(n *FibonacciNode[T], value T) error {
+	if n.removed {
+		return errors.New("node not in heap")
+	}
+
+	if fh.lessFn(n.value, value) {
+		return errors.New("value comes out after the current value")
+	}
+
+	n.value = value
+	parent := n.parent
+
+	if parent != nil && fh.before(n, parent) {
+		fh.cut(n)
+		fh.cascadingCut(parent)
+	}
+
+	if fh.before(n, fh.first) {
+		fh.first = n
+	}
+
+	return nil
+}
+
+// Meld moves every element of other into this heap, leaving other empty, both heaps must
+// use the same ordering, O(1)
+func (fh *FibonacciHeap[T]) Meld(other *FibonacciHeap[T]) {
+	if other == fh || other.first == nil {
+		return
+	}
+
+	if fh.first == nil {
+		fh.first = other.first
+	} else {
+		fh.splice(fh.first, other.first)
+
+		if fh.before(other.first, fh.first) {
+			fh.first = other.first
+		}
+	}
+
+	fh.size += other.size
+
+	other.first = nil
+	other.size = 0
+}
+
+// before reports whether node a comes out strictly before node b
+func (fh *FibonacciHeap[T]) before(a, b *FibonacciNode[T]) bool {
+	if fh.lessFn(a.value, b.value) {
+		return true
+	}
+
+	if fh.stable && !fh.lessFn(b.value, a.value) {
+		return a.seq < b.seq
+	}
+
+	return false
+}
+
+// addRoot adds a single node to the root list, keeping first on the root that comes out first
+func (fh *FibonacciHeap[T]) addRoot(n *FibonacciNode[T]) {
+	if fh.first == nil {
+		fh.first = n
+		return
+	}
+
+	fh.splice(fh.first, n)
+
+	if fh.before(n, fh.first) {
+		fh.first = n
+	}
+}
+
+// splice joins the circular lists holding a and b into one list
+func (fh *FibonacciHeap[T]) splice(a, b *FibonacciNode[T]) {
+	aRight := a.right
+	bLeft := b.left
+
+	a.right = b
+	b.left = a
+	bLeft.right = aRight
+	aRight.left = bLeft
+}
+
+// unlink removes n from its sibling list, leaving the pointers of n untouched
+func (fh *FibonacciHeap[T]) unlink(n *FibonacciNode[T]) {
+	n.left.right = n.right
+	n.right.left = n.left
+}
+
+// consolidate links roots of equal degree until every root has a distinct degree
+func (fh *FibonacciHeap[T]) consolidate() {
+	var roots []*FibonacciNode[T]
+
+	for current := fh.first; ; {
+		roots = append(roots, current)
+		current = current.right
+
+		if current == fh.first {
+			break
+		}
+	}
+
+	var byDegree []*FibonacciNode[T]
+
+	for _, root := range roots {
+		root.left = root
+		root.right = root
+
+		for {
+			for len(byDegree) <= root.degree {
+				byDegree = append(byDegree, nil)
+			}
+
+			other := byDegree[root.degree]
+			if other == nil {
+				break
+			}
+
+			byDegree[root.degree] = nil
+
+			if fh.before(other, root) {
+				root, other = other, root
+			}
+
+			fh.link(other, root)
+		}
+
+		byDegree[root.degree] = root
+	}
+
+	fh.first = nil
+
+	for _, root := range byDegree {
+		if root != nil {
+			root.left = root
+			root.right = root
+			fh.addRoot(root)
+		}
+	}
+}
+
+// link makes the single node child a child of parent
+func (fh *FibonacciHeap[T]) link(child, parent *FibonacciNode[T]) {
+	child.parent = parent
+	child.marked = false
+
+	if parent.child == nil {
+		parent.child = child
+	} else {
+		fh.splice(parent.child, child)
+	}
+
+	parent.degree++
+}
+
+// cut moves n from the children of its parent to the root list
+func (fh *FibonacciHeap[T]) cut(n *FibonacciNode[T]) {
+	parent := n.parent
+
+	if n == n.right {
+		parent.child = nil
+	} else {
+		if parent.child == n {
+			parent.child = n.right
+		}
+
+		fh.unlink(n)
+	}
+
+	parent.degree--
+	n.left = n
+	n.right = n
+	n.parent = nil
+	n.marked = false
+	fh.splice(fh.first, n)
+}
+
+// cascadingCut cuts every marked ancestor, marking the first one that was not
+func (fh *FibonacciHeap[T]) cascadingCut(n *FibonacciNode[T]) {
+	for n.parent != nil {
+		if !n.marked {
+			n.marked = true
+			return
+		}
+
+		parent := n.parent
+		fh.cut(n)
+		n = parent
+	}
+}
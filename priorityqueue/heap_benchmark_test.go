@@ -0,0 +1,290 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+const (
+	// decreaseKeySize is the number of elements held while keys are decreased
+	decreaseKeySize = 1 << 16
+	// meldSize is the number of elements in every heap melded into the accumulator
+	meldSize = 16
+	// meldRound is the number of heaps melded before the accumulator is rebuilt
+	meldRound = 1024
+	// throughputSize is the number of elements every throughput benchmark moves through a queue
+	throughputSize = 10_000_000
+)
+
+// benchValues returns n pseudo random values, the same ones on every run
+func benchValues(n int) []int {
+	r := rand.New(rand.NewPCG(1, 2))
+	values := make([]int, n)
+
+	for i := range values {
+		values[i] = r.Int()
+	}
+
+	return values
+}
+
+func BenchmarkPairingEnqueue(b *testing.B) {
+	values := benchValues(b.N)
+	ph := InitPairing(cmp.Less[int])
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ph.Enqueue(values[i])
+	}
+}
+
+func BenchmarkFibonacciEnqueue(b *testing.B) {
+	values := benchValues(b.N)
+	fh := InitFibonacci(cmp.Less[int])
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		fh.Enqueue(values[i])
+	}
+}
+
+func BenchmarkBinaryEnqueue(b *testing.B) {
+	values := benchValues(b.N)
+	pq := InitOrdered[int](nil)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		pq.Enqueue(values[i])
+	}
+}
+
+func BenchmarkPairingDequeue(b *testing.B) {
+	ph := InitPairing(cmp.Less[int])
+
+	for _, v := range benchValues(b.N) {
+		ph.Enqueue(v)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ph.Dequeue()
+	}
+}
+
+func BenchmarkFibonacciDequeue(b *testing.B) {
+	fh := InitFibonacci(cmp.Less[int])
+
+	for _, v := range benchValues(b.N) {
+		fh.Enqueue(v)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		fh.Dequeue()
+	}
+}
+
+func BenchmarkBinaryDequeue(b *testing.B) {
+	pq := InitOrdered(benchValues(b.N))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		pq.Dequeue()
+	}
+}
+
+// The Meld benchmarks report the cost of melding one heap of meldSize elements into an
+// accumulator holding up to meldRound of them, building the heaps is not timed
+func BenchmarkPairingMeld(b *testing.B) {
+	values := benchValues(meldRound * meldSize)
+	heaps := make([]*PairingHeap[int], meldRound)
+	var acc *PairingHeap[int]
+
+	for i := 0; i < b.N; i++ {
+		if i%meldRound == 0 {
+			b.StopTimer()
+			acc = InitPairing(cmp.Less[int])
+
+			for h := range heaps {
+				heaps[h] = InitPairing(cmp.Less[int])
+
+				for _, v := range values[h*meldSize : (h+1)*meldSize] {
+					heaps[h].Enqueue(v)
+				}
+			}
+
+			b.StartTimer()
+		}
+
+		acc.Meld(heaps[i%meldRound])
+	}
+}
+
+func BenchmarkFibonacciMeld(b *testing.B) {
+	values := benchValues(meldRound * meldSize)
+	heaps := make([]*FibonacciHeap[int], meldRound)
+	var acc *FibonacciHeap[int]
+
+	for i := 0; i < b.N; i++ {
+		if i%meldRound == 0 {
+			b.StopTimer()
+			acc = InitFibonacci(cmp.Less[int])
+
+			for h := range heaps {
+				heaps[h] = InitFibonacci(cmp.Less[int])
+
+				for _, v := range values[h*meldSize : (h+1)*meldSize] {
+					heaps[h].Enqueue(v)
+				}
+			}
+
+			b.StartTimer()
+		}
+
+		acc.Meld(heaps[i%meldRound])
+	}
+}
+
+// A binary heap has no Meld, every element of the other heap is enqueued instead, O(m log n)
+func BenchmarkBinaryMeld(b *testing.B) {
+	values := benchValues(meldRound * meldSize)
+	heaps := make([]*PriorityQueue[int, int], meldRound)
+	var acc *PriorityQueue[int, int]
+
+	for i := 0; i < b.N; i++ {
+		if i%meldRound == 0 {
+			b.StopTimer()
+			acc = InitOrdered[int](nil)
+
+			for h := range heaps {
+				heaps[h] = InitOrdered(values[h*meldSize : (h+1)*meldSize])
+			}
+
+			b.StartTimer()
+		}
+
+		other := heaps[i%meldRound]
+
+		for _, v := range other.heap {
+			acc.Enqueue(v)
+		}
+
+		other.Clear()
+	}
+}
+
+// The DecreaseKey benchmarks lower a random key of a heap holding decreaseKeySize elements,
+// after a Dequeue so the pairing and Fibonacci heaps are not a flat list of roots
+func BenchmarkPairingDecreaseKey(b *testing.B) {
+	values := benchValues(decreaseKeySize)
+	ph := InitPairing(cmp.Less[int])
+	nodes := make([]*PairingNode[int], decreaseKeySize)
+
+	for i, v := range values {
+		nodes[i] = ph.Enqueue(v)
+	}
+
+	ph.Enqueue(-1 << 62)
+	ph.Dequeue()
+
+	r := rand.New(rand.NewPCG(3, 4))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		n := nodes[r.IntN(decreaseKeySize)]
+		ph.DecreaseKey(n, n.Value()-r.IntN(1<<20))
+	}
+}
+
+func BenchmarkFibonacciDecreaseKey(b *testing.B) {
+	values := benchValues(decreaseKeySize)
+	fh := InitFibonacci(cmp.Less[int])
+	nodes := make([]*FibonacciNode[int], decreaseKeySize)
+
+	for i, v := range values {
+		nodes[i] = fh.Enqueue(v)
+	}
+
+	fh.Enqueue(-1 << 62)
+	fh.Dequeue()
+
+	r := rand.New(rand.NewPCG(3, 4))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		n := nodes[r.IntN(decreaseKeySize)]
+		fh.DecreaseKey(n, n.Value()-r.IntN(1<<20))
+	}
+}
+
+// PriorityQueue cannot address an element, so the binary heap is the Indexed Priority Queue
+func BenchmarkBinaryDecreaseKey(b *testing.B) {
+	values := benchValues(decreaseKeySize)
+	pq := InitIndexedOrdered[struct{}, int]()
+	handles := make([]Handle, decreaseKeySize)
+
+	for i, v := range values {
+		handles[i] = pq.Enqueue(struct{}{}, v)
+	}
+
+	r := rand.New(rand.NewPCG(3, 4))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		handle := handles[r.IntN(decreaseKeySize)]
+		_, priority, _ := pq.Get(handle)
+		pq.DecreaseKey(handle, priority-r.IntN(1<<20))
+	}
+}
+
+// The throughput benchmarks enqueue throughputSize elements into an empty queue, or
+// dequeue every element of a full one, for every arity, and report the time per element
+func BenchmarkEnqueueThroughput(b *testing.B) {
+	if testing.Short() {
+		b.Skip("skipping throughput benchmark in short mode")
+	}
+
+	values := benchValues(throughputSize)
+
+	for _, arity := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("arity=%d", arity), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				pq := InitOrdered[int](nil, WithArity[int](arity))
+
+				for _, v := range values {
+					pq.Enqueue(v)
+				}
+			}
+
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*throughputSize), "ns/element")
+		})
+	}
+}
+
+func BenchmarkDequeueThroughput(b *testing.B) {
+	if testing.Short() {
+		b.Skip("skipping throughput benchmark in short mode")
+	}
+
+	values := benchValues(throughputSize)
+
+	for _, arity := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("arity=%d", arity), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				pq := InitOrdered(values, WithArity[int](arity))
+				b.StartTimer()
+
+				for !pq.IsEmpty() {
+					pq.Dequeue()
+				}
+			}
+
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*throughputSize), "ns/element")
+		})
+	}
+}
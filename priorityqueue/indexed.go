@@ -0,0 +1,313 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"errors"
+)
+
+// Handle identifies an item of an Indexed Priority Queue, it stays valid until the item
+// is removed and is never reused, the zero Handle never refers to an item
+type Handle uint64
+
+// indexedItem represents a value enqueued with its priority
+type indexedItem[V any, P any] struct {
+	handle   Handle
+	value    V
+	priority P
+}
+
+// IndexedPriorityQueue represents Priority Queue data structure whose items are addressed
+// by the Handle returned from Enqueue, so the priority of an item can be changed in place,
+// a map holds the heap index of every handle
+type IndexedPriorityQueue[V any, P any] struct {
+	heap       []indexedItem[V, P]
+	positions  map[Handle]int
+	lessFn     func(a, b P) bool
+	stable     bool
+	arity      int
+	nextHandle Handle
+}
+
+// Initialize an empty Indexed Priority Queue, less reports whether priority a comes out
+// before priority b, it may be nil when WithComparator is given
+func InitIndexed[V any, P any](less func(a, b P) bool, opts ...Option[P]) *IndexedPriorityQueue[V, P] {
+	o := buildOptions(less, opts)
+
+	return &IndexedPriorityQueue[V, P]{
+		heap:      make([]indexedItem[V, P], 0, capacity),
+		positions: make(map[Handle]int),
+		lessFn:    o.less,
+		stable:    o.stable,
+		arity:     o.arity,
+	}
+}
+
+// InitIndexedOrdered initializes an empty Indexed Priority Queue with ordered priorities,
+// lowest first, or highest first with WithMaxHeap
+func InitIndexedOrdered[V any, P cmp.Ordered](opts ...Option[P]) *IndexedPriorityQueue[V, P] {
+	return InitIndexed[V](cmp.Less[P], opts...)
+}
+
+func (pq *IndexedPriorityQueue[V, P]) Size() int {
+	return len(pq.heap)
+}
+
+func (pq *IndexedPriorityQueue[V, P]) IsEmpty() bool {
+	return len(pq.heap) == 0
+}
+
+// Check if the item referred to by handle is in Heap, O(1)
+func (pq *IndexedPriorityQueue[V, P]) Contains(handle Handle) bool {
+	_, ok := pq.positions[handle]
+
+	return ok
+}
+
+// Add value with the given priority into Heap and return its handle, O(log n)
+func (pq *IndexedPriorityQueue[V, P]) Enqueue(value V, priority P) Handle {
+	pq.nextHandle++
+	handle := pq.nextHandle
+
+	pq.heap = append(pq.heap, indexedItem[V, P]{handle: handle, value: value, priority: priority})
+	pq.positions[handle] = len(pq.heap) - 1
+	pq.floatUp(len(pq.heap) - 1)
+
+	return handle
+}
+
+// Peek returns the value and priority of the item that comes out next
+func (pq *IndexedPriorityQueue[V, P]) Peek() (V, P, error) {
+	if pq.IsEmpty() {
+		var value V
+		var priority P
+		return value, priority, errors.New("priority queue is empty")
+	}
+
+	return pq.heap[0].value, pq.heap[0].priority, nil
+}
+
+// PeekHandle returns the handle of the item that comes out next
+func (pq *IndexedPriorityQueue[V, P]) PeekHandle() (Handle, error) {
+	if pq.IsEmpty() {
+		return 0, errors.New("priority queue is empty")
+	}
+
+	return pq.heap[0].handle, nil
+}
+
+func (pq *IndexedPriorityQueue[V, P]) Dequeue() (V, P, error) {
+	if pq.IsEmpty() {
+		var value V
+		var priority P
+		return value, priority, errors.New("priority queue is empty")
+	}
+
+	removed := pq.removeAt(0)
+
+	return removed.value, removed.priority, nil
+}
+
+// Remove method, removes the item referred to by handle from Heap, O(log n)
+func (pq *IndexedPriorityQueue[V, P]) Remove(handle Handle) (V, P, error) {
+	index, ok := pq.positions[handle]
+	if !ok {
+		var value V
+		var priority P
+		return value, priority, errors.New("handle not found")
+	}
+
+	removed := pq.removeAt(index)
+
+	return removed.value, removed.priority, nil
+}
+
+// Get returns the value and priority of the item referred to by handle, O(1)
+func (pq *IndexedPriorityQueue[V, P]) Get(handle Handle) (V, P, error) {
+	index, ok := pq.positions[handle]
+	if !ok {
+		var value V
+		var priority P
+		return value, priority, errors.New("handle not found")
+	}
+
+	return pq.heap[index].value, pq.heap[index].priority, nil
+}
+
+// UpdatePriority changes the priority of the item referred to by handle, O(log n)
+func (pq *IndexedPriorityQueue[V, P]) UpdatePriority(handle Handle, priority P) error {
+	index, ok := pq.positions[handle]
+	if !ok {
+		return errors.New("handle not found")
+	}
+
+	pq.heap[index].priority = priority
+
+	// Nothing moved up, so the item may have to move down instead
+	if pq.floatUp(index) == index {
+		pq.bubbleDown(index)
+	}
+
+	return nil
+}
+
+// DecreaseKey moves the item referred to by handle towards the front, priority must not
+// come out after its current priority, O(log n)
+func (pq *IndexedPriorityQueue[V, P]) DecreaseKey(handle Handle, priority P) error {
+	index, ok := pq.positions[handle]
+	if !ok {
+		return errors.New("handle not found")
+	}
+
+	if pq.lessFn(pq.heap[index].priority, priority) {
+		return errors.New("priority comes out after the current priority")
+	}
+
+	pq.heap[index].priority = priority
+	pq.floatUp(index)
+
+	return nil
+}
+
+// IncreaseKey moves the item referred to by handle towards the back, priority must not
+// come out before its current priority, O(log n)
+func (pq *IndexedPriorityQueue[V, P]) IncreaseKey(handle Handle, priority P) error {
+	index, ok := pq.positions[handle]
+	if !ok {
+		return errors.New("handle not found")
+	}
+
+	if pq.lessFn(priority, pq.heap[index].priority) {
+		return errors.New("priority comes out before the current priority")
+	}
+
+	pq.heap[index].priority = priority
+	pq.bubbleDown(index)
+
+	return nil
+}
+
+// Check if Heap Invariant is satisfied for the subtree at index, and every handle maps to its index
+func (pq *IndexedPriorityQueue[V, P]) IsHeap(index int) bool {
+	if index >= len(pq.heap) {
+		return true
+	}
+
+	if pq.positions[pq.heap[index].handle] != index {
+		return false
+	}
+
+	firstChild := index*pq.arity + 1
+
+	for child := firstChild; child < min(firstChild+pq.arity, len(pq.heap)); child++ {
+		if pq.less(child, index) || !pq.IsHeap(child) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// removeAt swaps the item at index with the last one, then restores Heap Invariant
+func (pq *IndexedPriorityQueue[V, P]) removeAt(index int) indexedItem[V, P] {
+	last := len(pq.heap) - 1
+	removed := pq.heap[index]
+
+	pq.swap(index, last)
+	pq.heap[last] = indexedItem[V, P]{}
+	pq.heap = pq.heap[:last]
+	delete(pq.positions, removed.handle)
+
+	if index < last {
+		if pq.floatUp(index) == index {
+			pq.bubbleDown(index)
+		}
+	}
+
+	return removed
+}
+
+// less reports whether the item at i comes out strictly before the item at j
+func (pq *IndexedPriorityQueue[V, P]) less(i, j int) bool {
+	return pq.before(pq.heap[i], pq.heap[j])
+}
+
+// before reports whether item a comes out strictly before item b, in stable mode equal
+// priorities are ordered by handle, which increases with every Enqueue
+func (pq *IndexedPriorityQueue[V, P]) before(a, b indexedItem[V, P]) bool {
+	if pq.lessFn(a.priority, b.priority) {
+		return true
+	}
+
+	return pq.stable && !pq.lessFn(b.priority, a.priority) && a.handle < b.handle
+}
+
+// floatUp moves the item at index up by shifting every parent that comes out after it one
+// level down into the hole, returning its new index
+func (pq *IndexedPriorityQueue[V, P]) floatUp(index int) int {
+	item := pq.heap[index]
+
+	for index > 0 {
+		parent := (index - 1) / pq.arity
+
+		if !pq.before(item, pq.heap[parent]) {
+			break
+		}
+
+		pq.move(parent, index)
+		index = parent
+	}
+
+	pq.place(item, index)
+
+	return index
+}
+
+// bubbleDown moves the item at index down by shifting the child that comes out first one
+// level up into the hole while it comes out before the item, returning its new index
+func (pq *IndexedPriorityQueue[V, P]) bubbleDown(index int) int {
+	item := pq.heap[index]
+
+	for {
+		firstChild := index*pq.arity + 1
+		if firstChild >= len(pq.heap) {
+			break
+		}
+
+		smallest := firstChild
+
+		for child := firstChild + 1; child < min(firstChild+pq.arity, len(pq.heap)); child++ {
+			if pq.less(child, smallest) {
+				smallest = child
+			}
+		}
+
+		if !pq.before(pq.heap[smallest], item) {
+			break
+		}
+
+		pq.move(smallest, index)
+		index = smallest
+	}
+
+	pq.place(item, index)
+
+	return index
+}
+
+// move copies the item at from into the hole at to
+func (pq *IndexedPriorityQueue[V, P]) move(from, to int) {
+	pq.heap[to] = pq.heap[from]
+	pq.positions[pq.heap[to].handle] = to
+}
+
+// place drops an item held out of Heap into the hole at index
+func (pq *IndexedPriorityQueue[V, P]) place(item indexedItem[V, P], index int) {
+	pq.heap[index] = item
+	pq.positions[item.handle] = index
+}
+
+func (pq *IndexedPriorityQueue[V, P]) swap(i, j int) {
+	pq.heap[i], pq.heap[j] = pq.heap[j], pq.heap[i]
+	pq.positions[pq.heap[i].handle] = i
+	pq.positions[pq.heap[j].handle] = j
+}
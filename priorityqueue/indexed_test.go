@@ -0,0 +1,186 @@
+package priorityqueue
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// queuedItem represents the value and priority the reference holds for a handle
+type queuedItem struct {
+	value    int
+	priority int
+}
+
+// checkIndexed compares every handle of the reference and the front of the queue with the reference
+func checkIndexed(t *testing.T, pq *IndexedPriorityQueue[int, int], entries map[Handle]queuedItem, gone []Handle) {
+	t.Helper()
+
+	if !pq.IsHeap(0) || pq.Size() != len(entries) {
+		t.Fatalf("heap invariant broken or Size() = %d, want %d", pq.Size(), len(entries))
+	}
+
+	for handle, entry := range entries {
+		value, priority, err := pq.Get(handle)
+
+		if err != nil || value != entry.value || priority != entry.priority || !pq.Contains(handle) {
+			t.Fatalf("Get(%d) = %d, %d, %v, want %v", handle, value, priority, err, entry)
+		}
+	}
+
+	for _, handle := range gone {
+		if pq.Contains(handle) {
+			t.Fatalf("Contains(%d) = true after it left the queue", handle)
+		}
+	}
+
+	if len(entries) == 0 {
+		return
+	}
+
+	lowest := minPriority(entries)
+	handle, err := pq.PeekHandle()
+
+	if err != nil || entries[handle].priority != lowest {
+		t.Fatalf("PeekHandle() = %d with priority %d, want priority %d", handle, entries[handle].priority, lowest)
+	}
+
+	if value, priority, _ := pq.Peek(); value != entries[handle].value || priority != lowest {
+		t.Fatalf("Peek() = %d, %d, want the item of handle %d", value, priority, handle)
+	}
+}
+
+func minPriority(entries map[Handle]queuedItem) int {
+	lowest := 0
+	first := true
+
+	for _, entry := range entries {
+		if first || entry.priority < lowest {
+			lowest, first = entry.priority, false
+		}
+	}
+
+	return lowest
+}
+
+func TestIndexedRandom(t *testing.T) {
+	for _, arity := range []int{2, 3, 4} {
+		t.Run(fmt.Sprintf("arity %d", arity), func(t *testing.T) {
+			r := rand.New(rand.NewPCG(uint64(arity), 12))
+			pq := InitIndexedOrdered[int](WithArity[int](arity))
+			entries := make(map[Handle]queuedItem)
+			handles := make(map[Handle]int)
+			var gone []Handle
+
+			randomHandle := func() Handle {
+				// Removed handles are picked too, to exercise the error paths
+				if len(gone) > 0 && r.IntN(5) == 0 {
+					return gone[r.IntN(len(gone))]
+				}
+
+				if len(entries) == 0 {
+					return 0
+				}
+
+				held := slices.Sorted(maps.Keys(entries))
+				return held[r.IntN(len(held))]
+			}
+
+			for step := 0; step < 3000; step++ {
+				handle := randomHandle()
+				entry, live := entries[handle]
+				priority := r.IntN(100)
+
+				switch op := r.IntN(7); op {
+				case 0, 1:
+					added := pq.Enqueue(step, priority)
+
+					if _, reused := handles[added]; reused {
+						t.Fatalf("step %d: handle %d reused", step, added)
+					}
+
+					handles[added] = step
+					entries[added] = queuedItem{step, priority}
+				case 2:
+					value, got, err := pq.Dequeue()
+
+					if len(entries) == 0 {
+						if err == nil {
+							t.Fatalf("step %d: Dequeue on empty queue returned no error", step)
+						}
+
+						continue
+					}
+
+					if err != nil || got != minPriority(entries) {
+						t.Fatalf("step %d: Dequeue() priority %d, %v, want %d", step, got, err, minPriority(entries))
+					}
+
+					for h, e := range entries {
+						if e.value == value {
+							delete(entries, h)
+							gone = append(gone, h)
+						}
+					}
+				case 3:
+					value, got, err := pq.Remove(handle)
+
+					if !live {
+						if err == nil {
+							t.Fatalf("step %d: Remove(%d) of a missing handle returned no error", step, handle)
+						}
+
+						continue
+					}
+
+					if err != nil || value != entry.value || got != entry.priority {
+						t.Fatalf("step %d: Remove(%d) = %d, %d, %v, want %v", step, handle, value, got, err, entry)
+					}
+
+					delete(entries, handle)
+					gone = append(gone, handle)
+				case 4:
+					err := pq.DecreaseKey(handle, priority)
+
+					if valid := live && priority <= entry.priority; valid != (err == nil) {
+						t.Fatalf("step %d: DecreaseKey(%d, %d) from %v = %v", step, handle, priority, entry, err)
+					}
+
+					if err == nil {
+						entries[handle] = queuedItem{entry.value, priority}
+					}
+				case 5:
+					err := pq.IncreaseKey(handle, priority)
+
+					if valid := live && priority >= entry.priority; valid != (err == nil) {
+						t.Fatalf("step %d: IncreaseKey(%d, %d) from %v = %v", step, handle, priority, entry, err)
+					}
+
+					if err == nil {
+						entries[handle] = queuedItem{entry.value, priority}
+					}
+				default:
+					err := pq.UpdatePriority(handle, priority)
+
+					if live != (err == nil) {
+						t.Fatalf("step %d: UpdatePriority(%d) = %v", step, handle, err)
+					}
+
+					if err == nil {
+						entries[handle] = queuedItem{entry.value, priority}
+					}
+				}
+
+				checkIndexed(t, pq, entries, gone)
+			}
+
+			for _, handle := range gone {
+				if _, _, err := pq.Get(handle); err == nil {
+					t.Fatalf("Get(%d) of a removed handle returned no error", handle)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// meldableHeap is the API shared by the pairing and Fibonacci heaps of ints
+type meldableHeap[H any, N any] interface {
+	Size() int
+	IsEmpty() bool
+	Peek() (int, error)
+	Dequeue() (int, error)
+	Enqueue(element int) N
+	DecreaseKey(n N, value int) error
+	Meld(other H)
+}
+
+// valued is the API shared by their nodes
+type valued interface {
+	comparable
+	Value() int
+}
+
+// testMeldableRandom runs random operations over a few heaps against a reference holding
+// the live nodes of every heap, values are kept unique so a dequeued value names its node
+func testMeldableRandom[H meldableHeap[H, N], N valued](t *testing.T, newHeap func() H) {
+	r := rand.New(rand.NewPCG(4, 6))
+	heaps := []H{newHeap(), newHeap(), newHeap()}
+	live := []map[N]int{{}, {}, {}}
+	used := make(map[int]bool)
+	var removed []N
+
+	fresh := func(below int) int {
+		for {
+			v := below - 1 - r.IntN(1<<20)
+			if !used[v] {
+				used[v] = true
+				return v
+			}
+		}
+	}
+
+	for step := 0; step < 5000; step++ {
+		i := r.IntN(len(heaps))
+		h := heaps[i]
+
+		switch op := r.IntN(10); {
+		case op < 4:
+			v := fresh(1 << 40)
+			live[i][h.Enqueue(v)] = v
+		case op < 6:
+			got, err := h.Dequeue()
+
+			if len(live[i]) == 0 {
+				if err == nil {
+					t.Fatalf("step %d: Dequeue on empty heap returned %d", step, got)
+				}
+
+				continue
+			}
+
+			want := slices.Min(slices.Collect(maps.Values(live[i])))
+
+			if err != nil || got != want {
+				t.Fatalf("step %d: Dequeue() = %d, %v, want %d", step, got, err, want)
+			}
+
+			for n, v := range live[i] {
+				if v == want {
+					delete(live[i], n)
+					removed = append(removed, n)
+				}
+			}
+		case op < 9:
+			if len(live[i]) == 0 {
+				continue
+			}
+
+			nodes := slices.Collect(maps.Keys(live[i]))
+			n := nodes[r.IntN(len(nodes))]
+			v := fresh(live[i][n])
+
+			if err := h.DecreaseKey(n, v); err != nil {
+				t.Fatalf("step %d: DecreaseKey: %v", step, err)
+			}
+
+			live[i][n] = v
+		default:
+			j := (i + 1) % len(heaps)
+			h.Meld(heaps[j])
+			maps.Copy(live[i], live[j])
+			clear(live[j])
+		}
+
+		for k, heap := range heaps {
+			if heap.Size() != len(live[k]) || heap.IsEmpty() != (len(live[k]) == 0) {
+				t.Fatalf("step %d: heap %d Size() = %d, want %d", step, k, heap.Size(), len(live[k]))
+			}
+
+			if top, err := heap.Peek(); len(live[k]) > 0 && (err != nil || top != slices.Min(slices.Collect(maps.Values(live[k])))) {
+				t.Fatalf("step %d: heap %d Peek() = %d, %v", step, k, top, err)
+			}
+		}
+	}
+
+	for _, n := range removed[:min(len(removed), 50)] {
+		if err := heaps[0].DecreaseKey(n, -1<<40); err == nil {
+			t.Fatal("DecreaseKey accepted a removed node")
+		}
+	}
+
+	for k, heap := range heaps {
+		want := slices.Sorted(maps.Values(live[k]))
+		var got []int
+
+		for !heap.IsEmpty() {
+			v, err := heap.Dequeue()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got = append(got, v)
+		}
+
+		if !slices.Equal(got, want) {
+			t.Fatalf("heap %d drained as %v, want %v", k, got, want)
+		}
+	}
+}
+
+// testDecreaseKeyErrors checks that a larger value and a removed node are rejected without change
+func testDecreaseKeyErrors[H meldableHeap[H, N], N valued](t *testing.T, newHeap func() H) {
+	h := newHeap()
+	n := h.Enqueue(10)
+	h.Enqueue(20)
+
+	if err := h.DecreaseKey(n, 11); err == nil {
+		t.Fatal("DecreaseKey accepted a larger value")
+	}
+
+	if n.Value() != 10 {
+		t.Fatalf("rejected DecreaseKey changed the value to %d", n.Value())
+	}
+
+	if err := h.DecreaseKey(n, 10); err != nil {
+		t.Fatalf("DecreaseKey to the same value: %v", err)
+	}
+
+	if v, _ := h.Dequeue(); v != 10 {
+		t.Fatalf("Dequeue() = %d, want 10", v)
+	}
+
+	if err := h.DecreaseKey(n, 0); err == nil {
+		t.Fatal("DecreaseKey accepted a dequeued node")
+	}
+
+	if top, _ := h.Peek(); top != 20 || h.Size() != 1 {
+		t.Fatalf("Peek() = %d with size %d, want 20 with size 1", top, h.Size())
+	}
+}
+
+// testMeldEmptiesOther checks sizes after Meld and that other stays usable
+func testMeldEmptiesOther[H meldableHeap[H, N], N valued](t *testing.T, newHeap func() H) {
+	a, b := newHeap(), newHeap()
+
+	for _, v := range []int{5, 3, 8} {
+		a.Enqueue(v)
+	}
+
+	for _, v := range []int{7, 1} {
+		b.Enqueue(v)
+	}
+
+	a.Meld(b)
+
+	if a.Size() != 5 || b.Size() != 0 || !b.IsEmpty() {
+		t.Fatalf("sizes after Meld = %d and %d, want 5 and 0", a.Size(), b.Size())
+	}
+
+	if _, err := b.Peek(); err == nil {
+		t.Fatal("Peek on a melded away heap returned no error")
+	}
+
+	a.Meld(b)
+	a.Meld(a)
+
+	if a.Size() != 5 {
+		t.Fatalf("Size() = %d after melding an empty heap and itself, want 5", a.Size())
+	}
+
+	b.Enqueue(4)
+
+	if v, err := b.Dequeue(); err != nil || v != 4 || !b.IsEmpty() {
+		t.Fatalf("melded away heap unusable: Dequeue() = %d, %v", v, err)
+	}
+
+	var got []int
+
+	for !a.IsEmpty() {
+		v, _ := a.Dequeue()
+		got = append(got, v)
+	}
+
+	if want := []int{1, 3, 5, 7, 8}; !slices.Equal(got, want) {
+		t.Fatalf("drained %v, want %v", got, want)
+	}
+}
+
+func TestPairingHeap(t *testing.T) {
+	newHeap := func() *PairingHeap[int] { return InitPairing(cmp.Less[int]) }
+
+	t.Run("random", func(t *testing.T) { testMeldableRandom(t, newHeap) })
+	t.Run("DecreaseKey errors", func(t *testing.T) { testDecreaseKeyErrors(t, newHeap) })
+	t.Run("Meld", func(t *testing.T) { testMeldEmptiesOther(t, newHeap) })
+}
+
+func TestFibonacciHeap(t *testing.T) {
+	newHeap := func() *FibonacciHeap[int] { return InitFibonacci(cmp.Less[int]) }
+
+	t.Run("random", func(t *testing.T) { testMeldableRandom(t, newHeap) })
+	t.Run("DecreaseKey errors", func(t *testing.T) { testDecreaseKeyErrors(t, newHeap) })
+	t.Run("Meld", func(t *testing.T) { testMeldEmptiesOther(t, newHeap) })
+}
+
+// Ties across melded heaps come out in Enqueue order, which the package level meldSequence
+// numbers across heaps
+func TestMeldKeepsStableOrder(t *testing.T) {
+	drain := func(pop func() (task, error), size func() int) []int {
+		var labels []int
+
+		for size() > 0 {
+			item, err := pop()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels = append(labels, item.label)
+		}
+
+		return labels
+	}
+
+	var items []task
+
+	for i := 0; i < 40; i++ {
+		items = append(items, task{priority: i % 2, label: i})
+	}
+
+	var want []int
+
+	for _, item := range fifoOrder(items) {
+		want = append(want, item.label)
+	}
+
+	pa, pb := InitPairing(taskLess, WithStableOrder[task]()), InitPairing(taskLess, WithStableOrder[task]())
+	fa, fb := InitFibonacci(taskLess, WithStableOrder[task]()), InitFibonacci(taskLess, WithStableOrder[task]())
+
+	// Alternate pairs of items between the heaps so every priority has ties in both
+	for i, item := range items {
+		if i%4 < 2 {
+			pb.Enqueue(item)
+			fb.Enqueue(item)
+		} else {
+			pa.Enqueue(item)
+			fa.Enqueue(item)
+		}
+	}
+
+	pa.Meld(pb)
+	fa.Meld(fb)
+
+	if got := drain(pa.Dequeue, pa.Size); !slices.Equal(got, want) {
+		t.Errorf("pairing heap drained as %v, want %v", got, want)
+	}
+
+	if got := drain(fa.Dequeue, fa.Size); !slices.Equal(got, want) {
+		t.Errorf("Fibonacci heap drained as %v, want %v", got, want)
+	}
+}
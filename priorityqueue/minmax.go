@@ -0,0 +1,246 @@
+package priorityqueue
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// MinMaxHeap represents a double ended Priority Queue laid out as a binary heap whose
+// levels alternate between min levels, starting with the root, and max levels, every
+// node on a min level comes out no later than its descendants and every node on a max
+// level comes out no earlier, so the first item is the root and the last one is one of
+// its children
+type MinMaxHeap[T any] struct {
+	heap   []T
+	lessFn func(a, b T) bool
+}
+
+// Initialize Min Max Heap, and heapify so it satisfy Min Max Heap Invariant in O(n), less
+// reports whether a comes out before b, it may be nil when WithComparator is given, only
+// the ordering options WithMaxHeap and WithComparator apply, WithStableOrder and WithArity
+// other than 2 panic
+func InitMinMax[T any](items []T, less func(a, b T) bool, opts ...Option[T]) *MinMaxHeap[T] {
+	o := buildOptions(less, opts)
+
+	if o.stable || o.arity != 2 {
+		panic("min max heap supports only WithMaxHeap and WithComparator")
+	}
+
+	mh := &MinMaxHeap[T]{
+		heap:   make([]T, len(items), max(len(items), capacity)),
+		lessFn: o.less,
+	}
+	copy(mh.heap, items)
+
+	// Heapify Process, O(n)
+	for i := len(mh.heap)/2 - 1; i >= 0; i-- {
+		mh.pushDown(i)
+	}
+
+	return mh
+}
+
+func (mh *MinMaxHeap[T]) Size() int {
+	return len(mh.heap)
+}
+
+func (mh *MinMaxHeap[T]) IsEmpty() bool {
+	return len(mh.heap) == 0
+}
+
+// Add element into Heap, O(log n)
+func (mh *MinMaxHeap[T]) Enqueue(element T) {
+	mh.heap = append(mh.heap, element)
+	mh.pushUp(len(mh.heap) - 1)
+}
+
+// PeekMin returns the element that comes out first, O(1)
+func (mh *MinMaxHeap[T]) PeekMin() (T, error) {
+	if mh.IsEmpty() {
+		var zero T
+		return zero, errors.New("priority queue is empty")
+	}
+
+	return mh.heap[0], nil
+}
+
+// PeekMax returns the element that comes out last, O(1)
+func (mh *MinMaxHeap[T]) PeekMax() (T, error) {
+	if mh.IsEmpty() {
+		var zero T
+		return zero, errors.New("priority queue is empty")
+	}
+
+	return mh.heap[mh.maxIndex()], nil
+}
+
+// PopMin removes the element that comes out first, O(log n)
+func (mh *MinMaxHeap[T]) PopMin() (T, error) {
+	if mh.IsEmpty() {
+		var zero T
+		return zero, errors.New("priority queue is empty")
+	}
+
+	return mh.removeAt(0), nil
+}
+
+// PopMax removes the element that comes out last, O(log n)
+func (mh *MinMaxHeap[T]) PopMax() (T, error) {
+	if mh.IsEmpty() {
+		var zero T
+		return zero, errors.New("priority queue is empty")
+	}
+
+	return mh.removeAt(mh.maxIndex()), nil
+}
+
+// Check if Min Max Heap Invariant is satisfied for the subtree at index, a node on a min
+// level comes out no later than its children and grandchildren, and a node on a max
+// level no earlier
+func (mh *MinMaxHeap[T]) IsMinMaxHeap(index int) bool {
+	if index >= len(mh.heap) {
+		return true
+	}
+
+	better := mh.betterOn(index)
+	firstChild := index*2 + 1
+	firstGrandchild := index*4 + 3
+
+	for descendant := firstChild; descendant < min(firstChild+2, len(mh.heap)); descendant++ {
+		if better(mh.heap[descendant], mh.heap[index]) {
+			return false
+		}
+	}
+
+	for descendant := firstGrandchild; descendant < min(firstGrandchild+4, len(mh.heap)); descendant++ {
+		if better(mh.heap[descendant], mh.heap[index]) {
+			return false
+		}
+	}
+
+	return mh.IsMinMaxHeap(firstChild) && mh.IsMinMaxHeap(firstChild+1)
+}
+
+// maxIndex returns the index of the element that comes out last, the heap must not be empty
+func (mh *MinMaxHeap[T]) maxIndex() int {
+	switch {
+	case len(mh.heap) == 1:
+		return 0
+	case len(mh.heap) == 2 || !mh.lessFn(mh.heap[1], mh.heap[2]):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// removeAt replaces the element at index with the last one, then pushes it down
+func (mh *MinMaxHeap[T]) removeAt(index int) T {
+	last := len(mh.heap) - 1
+	removed := mh.heap[index]
+
+	mh.heap[index] = mh.heap[last]
+
+	var zero T
+	mh.heap[last] = zero
+	mh.heap = mh.heap[:last]
+
+	if index < last {
+		mh.pushDown(index)
+	}
+
+	return removed
+}
+
+// isMinLevel reports whether index lies on a min level, levels are numbered from 0 at the root
+func isMinLevel(index int) bool {
+	return (bits.Len(uint(index+1))-1)%2 == 0
+}
+
+// betterOn returns the comparison that the node at index must win against its descendants
+func (mh *MinMaxHeap[T]) betterOn(index int) func(a, b T) bool {
+	if isMinLevel(index) {
+		return mh.lessFn
+	}
+
+	return func(a, b T) bool {
+		return mh.lessFn(b, a)
+	}
+}
+
+// pushDown moves the element at index down through the levels of its kind, swapping it
+// with the best of its children and grandchildren
+func (mh *MinMaxHeap[T]) pushDown(index int) {
+	better := mh.betterOn(index)
+
+	for {
+		firstChild := index*2 + 1
+		if firstChild >= len(mh.heap) {
+			return
+		}
+
+		best := firstChild
+		firstGrandchild := index*4 + 3
+
+		if firstChild+1 < len(mh.heap) && better(mh.heap[firstChild+1], mh.heap[best]) {
+			best = firstChild + 1
+		}
+
+		for grandchild := firstGrandchild; grandchild < min(firstGrandchild+4, len(mh.heap)); grandchild++ {
+			if better(mh.heap[grandchild], mh.heap[best]) {
+				best = grandchild
+			}
+		}
+
+		if !better(mh.heap[best], mh.heap[index]) {
+			return
+		}
+
+		mh.swap(best, index)
+
+		// A child is on the other kind of level and has no descendants left to compare
+		if best < firstGrandchild {
+			return
+		}
+
+		// The element now sits below a parent of the other kind, which it may have to swap with
+		parent := (best - 1) / 2
+
+		if better(mh.heap[parent], mh.heap[best]) {
+			mh.swap(parent, best)
+		}
+
+		index = best
+	}
+}
+
+// pushUp moves the element at index up through the levels of the kind it belongs to
+func (mh *MinMaxHeap[T]) pushUp(index int) {
+	if index == 0 {
+		return
+	}
+
+	parent := (index - 1) / 2
+
+	// The element belongs on the levels of its parent when it beats the parent there
+	if mh.betterOn(parent)(mh.heap[index], mh.heap[parent]) {
+		mh.swap(index, parent)
+		index = parent
+	}
+
+	better := mh.betterOn(index)
+
+	for index > 2 {
+		grandparent := ((index-1)/2 - 1) / 2
+
+		if !better(mh.heap[index], mh.heap[grandparent]) {
+			return
+		}
+
+		mh.swap(index, grandparent)
+		index = grandparent
+	}
+}
+
+func (mh *MinMaxHeap[T]) swap(i, j int) {
+	mh.heap[i], mh.heap[j] = mh.heap[j], mh.heap[i]
+}
@@ -0,0 +1,161 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// checkMinMax compares both ends of the heap with the sorted reference and checks the invariant
+func checkMinMax(t *testing.T, mh *MinMaxHeap[int], sorted []int) {
+	t.Helper()
+
+	if !mh.IsMinMaxHeap(0) {
+		t.Fatalf("invariant broken: %v", mh.heap)
+	}
+
+	if mh.Size() != len(sorted) || mh.IsEmpty() != (len(sorted) == 0) {
+		t.Fatalf("Size() = %d, want %d", mh.Size(), len(sorted))
+	}
+
+	if len(sorted) == 0 {
+		return
+	}
+
+	if first, err := mh.PeekMin(); err != nil || first != sorted[0] {
+		t.Fatalf("PeekMin() = %d, %v, want %d", first, err, sorted[0])
+	}
+
+	if last, err := mh.PeekMax(); err != nil || last != sorted[len(sorted)-1] {
+		t.Fatalf("PeekMax() = %d, %v, want %d", last, err, sorted[len(sorted)-1])
+	}
+}
+
+func TestMinMaxHeapRandom(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option[int]
+		compare func(a, b int) int
+	}{
+		{"min first", nil, cmp.Compare[int]},
+		{"max first", []Option[int]{WithMaxHeap[int]()}, func(a, b int) int { return cmp.Compare(b, a) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := uint64(0); seed < 20; seed++ {
+				r := rand.New(rand.NewPCG(seed, 5))
+				items := make([]int, r.IntN(40))
+
+				for i := range items {
+					items[i] = r.IntN(30)
+				}
+
+				mh := InitMinMax(items, cmp.Less[int], tt.opts...)
+				sorted := slices.SortedFunc(slices.Values(items), tt.compare)
+				checkMinMax(t, mh, sorted)
+
+				for step := 0; step < 300; step++ {
+					switch r.IntN(3) {
+					case 0:
+						v := r.IntN(30)
+						mh.Enqueue(v)
+						i, _ := slices.BinarySearchFunc(sorted, v, tt.compare)
+						sorted = slices.Insert(sorted, i, v)
+					case 1:
+						got, err := mh.PopMin()
+
+						if len(sorted) == 0 {
+							if err == nil {
+								t.Fatal("PopMin on empty heap returned no error")
+							}
+
+							continue
+						}
+
+						if err != nil || got != sorted[0] {
+							t.Fatalf("seed %d step %d: PopMin() = %d, %v, want %d", seed, step, got, err, sorted[0])
+						}
+
+						sorted = sorted[1:]
+					default:
+						got, err := mh.PopMax()
+
+						if len(sorted) == 0 {
+							if err == nil {
+								t.Fatal("PopMax on empty heap returned no error")
+							}
+
+							continue
+						}
+
+						if err != nil || got != sorted[len(sorted)-1] {
+							t.Fatalf("seed %d step %d: PopMax() = %d, %v, want %d", seed, step, got, err, sorted[len(sorted)-1])
+						}
+
+						sorted = sorted[:len(sorted)-1]
+					}
+
+					checkMinMax(t, mh, sorted)
+				}
+			}
+		})
+	}
+}
+
+func TestMinMaxHeapEmpty(t *testing.T) {
+	mh := InitMinMax(nil, cmp.Less[int])
+
+	if _, err := mh.PeekMin(); err == nil {
+		t.Error("PeekMin on empty heap returned no error")
+	}
+
+	if _, err := mh.PeekMax(); err == nil {
+		t.Error("PeekMax on empty heap returned no error")
+	}
+}
+
+func TestIsMinMaxHeapRejectsBadHeap(t *testing.T) {
+	tests := []struct {
+		name string
+		heap []int
+	}{
+		{"min level child comes out first", []int{5, 1, 9}},
+		{"max level child comes out last", []int{1, 9, 8, 10}},
+		{"min level grandchild comes out first", []int{5, 9, 8, 6, 7, 3}},
+		{"max level grandchild comes out last", []int{1, 9, 8, 2, 3, 4, 5, 6, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mh := &MinMaxHeap[int]{heap: tt.heap, lessFn: cmp.Less[int]}
+
+			if mh.IsMinMaxHeap(0) {
+				t.Errorf("IsMinMaxHeap accepted %v", tt.heap)
+			}
+		})
+	}
+}
+
+func TestInitMinMaxRejectsUnsupportedOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option[int]
+	}{
+		{"stable order", WithStableOrder[int]()},
+		{"arity", WithArity[int](4)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("InitMinMax accepted the option")
+				}
+			}()
+
+			InitMinMax(nil, cmp.Less[int], tt.opt)
+		})
+	}
+}
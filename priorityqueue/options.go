@@ -0,0 +1,68 @@
+package priorityqueue
+
+// Option configures a Priority Queue at Init
+type Option[T any] func(*options[T])
+
+// options represents the configuration built from the Init arguments
+type options[T any] struct {
+	less    func(a, b T) bool
+	maxHeap bool
+	stable  bool
+	arity   int
+}
+
+// WithMaxHeap reverses the ordering, so the item that would come out last comes out first
+func WithMaxHeap[T any]() Option[T] {
+	return func(o *options[T]) {
+		o.maxHeap = true
+	}
+}
+
+// WithComparator orders items by compare instead of less, compare returns a negative
+// number when a comes out before b, zero when they are equal and a positive number otherwise
+func WithComparator[T any](compare func(a, b T) int) Option[T] {
+	return func(o *options[T]) {
+		o.less = func(a, b T) bool {
+			return compare(a, b) < 0
+		}
+	}
+}
+
+// WithStableOrder breaks ties by insertion sequence, so items that compare equal come out
+// in the order they were enqueued
+func WithStableOrder[T any]() Option[T] {
+	return func(o *options[T]) {
+		o.stable = true
+	}
+}
+
+// WithArity lays the heap out with arity children per node instead of 2, a wider heap is
+// shallower so Enqueue does fewer moves while Dequeue compares more children per level,
+// 4 or 8 usually suit large queues best, arity must be at least 2
+func WithArity[T any](arity int) Option[T] {
+	if arity < 2 {
+		panic("arity must be at least 2")
+	}
+
+	return func(o *options[T]) {
+		o.arity = arity
+	}
+}
+
+// buildOptions applies opts over the default less and returns the resulting configuration
+func buildOptions[T any](less func(a, b T) bool, opts []Option[T]) *options[T] {
+	o := &options[T]{less: less, arity: 2}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.maxHeap {
+		ascending := o.less
+		o.less = func(a, b T) bool {
+			return ascending(b, a)
+		}
+	}
+
+	return o
+}
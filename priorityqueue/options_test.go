@@ -0,0 +1,151 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// extremes holds the values at both ends of int, which overflow when ordering is reversed by negation
+var extremes = []int{0, math.MaxInt, math.MinInt, -1, 1, math.MaxInt, math.MinInt + 1, 42, math.MaxInt - 1, math.MinInt}
+
+// drainers returns, for every queue type, a function that fills a queue built with opts
+// and less, which may be nil, then returns everything it held in the order it came out
+func drainers[T any](t *testing.T, less func(a, b T) bool) map[string]func(items []T, opts ...Option[T]) []T {
+	return map[string]func(items []T, opts ...Option[T]) []T{
+		"heapify": func(items []T, opts ...Option[T]) []T {
+			pq := Init[T, int](items, less, nil, opts...)
+			return drainAll(t, pq.Size, pq.Dequeue)
+		},
+		"enqueue": func(items []T, opts ...Option[T]) []T {
+			pq := Init[T, int](nil, less, nil, opts...)
+
+			for _, item := range items {
+				pq.Enqueue(item)
+			}
+
+			return drainAll(t, pq.Size, pq.Dequeue)
+		},
+		"indexed": func(items []T, opts ...Option[T]) []T {
+			pq := InitIndexed[int](less, opts...)
+
+			for i, item := range items {
+				pq.Enqueue(i, item)
+			}
+
+			return drainAll(t, pq.Size, func() (T, error) {
+				_, priority, err := pq.Dequeue()
+				return priority, err
+			})
+		},
+		"pairing": func(items []T, opts ...Option[T]) []T {
+			ph := InitPairing(less, opts...)
+
+			for _, item := range items {
+				ph.Enqueue(item)
+			}
+
+			return drainAll(t, ph.Size, ph.Dequeue)
+		},
+		"fibonacci": func(items []T, opts ...Option[T]) []T {
+			fh := InitFibonacci(less, opts...)
+
+			for _, item := range items {
+				fh.Enqueue(item)
+			}
+
+			return drainAll(t, fh.Size, fh.Dequeue)
+		},
+		"minmax": func(items []T, opts ...Option[T]) []T {
+			mh := InitMinMax(items, less, opts...)
+			return drainAll(t, mh.Size, mh.PopMin)
+		},
+		"bounded": func(items []T, opts ...Option[T]) []T {
+			bh := InitBounded(uint(len(items)), less, opts...)
+
+			for _, item := range items {
+				bh.Enqueue(item)
+			}
+
+			return bh.Items()
+		},
+		"blocking": func(items []T, opts ...Option[T]) []T {
+			bq := InitBlocking(uint(len(items)+1), less, opts...)
+
+			for _, item := range items {
+				if err := bq.Put(context.Background(), item); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			return drainAll(t, bq.Size, bq.TryTake)
+		},
+	}
+}
+
+func drainAll[T any](t *testing.T, size func() int, pop func() (T, error)) []T {
+	var out []T
+
+	for size() > 0 {
+		item, err := pop()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out = append(out, item)
+	}
+
+	return out
+}
+
+func TestMaxHeapWithExtremeValues(t *testing.T) {
+	want := slices.Clone(extremes)
+	slices.SortFunc(want, func(a, b int) int { return cmp.Compare(b, a) })
+
+	for name, drain := range drainers(t, cmp.Less[int]) {
+		if got := drain(extremes, WithMaxHeap[int]()); !slices.Equal(got, want) {
+			t.Errorf("%s: max heap drained as %v, want %v", name, got, want)
+		}
+	}
+
+	for _, arity := range []int{2, 3, 4, 8} {
+		pq := InitOrdered(extremes, WithMaxHeap[int](), WithArity[int](arity))
+
+		if got := drainAll(t, pq.Size, pq.Dequeue); !slices.Equal(got, want) {
+			t.Errorf("arity %d: max heap drained as %v, want %v", arity, got, want)
+		}
+	}
+}
+
+// byLength orders strings by length, then alphabetically
+func byLength(a, b string) int {
+	return cmp.Or(cmp.Compare(len(a), len(b)), strings.Compare(a, b))
+}
+
+func TestWithComparatorAndNilLess(t *testing.T) {
+	items := []string{"pear", "fig", "banana", "kiwi", "apple", "date", "plum", "melon"}
+
+	ascending := slices.SortedFunc(slices.Values(items), byLength)
+	descending := slices.Clone(ascending)
+	slices.Reverse(descending)
+
+	for name, drain := range drainers[string](t, nil) {
+		if got := drain(items, WithComparator(byLength)); !slices.Equal(got, ascending) {
+			t.Errorf("%s: drained as %v, want %v", name, got, ascending)
+		}
+
+		if got := drain(items, WithComparator(byLength), WithMaxHeap[string]()); !slices.Equal(got, descending) {
+			t.Errorf("%s with WithMaxHeap: drained as %v, want %v", name, got, descending)
+		}
+	}
+
+	// The comparator replaces less when both are given
+	pq := Init(items, func(a, b string) bool { return a < b }, Identity[string], WithComparator(byLength))
+
+	if got := drainAll(t, pq.Size, pq.Dequeue); !slices.Equal(got, ascending) {
+		t.Errorf("comparator over less: drained as %v, want %v", got, ascending)
+	}
+}
@@ -0,0 +1,209 @@
+package priorityqueue
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// meldSequence numbers the elements of every meldable heap in Enqueue order, it is shared
+// so that elements of melded heaps still compare by the order they were enqueued in
+var meldSequence atomic.Uint64
+
+// PairingNode represents an element of a Pairing Heap, it is returned by Enqueue so its
+// value can be changed with DecreaseKey, prev points to the parent for a first child
+// and to the previous sibling otherwise
+type PairingNode[T any] struct {
+	value   T
+	seq     uint64
+	child   *PairingNode[T]
+	sibling *PairingNode[T]
+	prev    *PairingNode[T]
+	removed bool
+}
+
+// PairingHeap represents a heap ordered tree where every node keeps its children in a
+// list, Enqueue, Meld and DecreaseKey link two trees in O(1), Dequeue pairs up the
+// children of the root in O(log n) amortized
+type PairingHeap[T any] struct {
+	root   *PairingNode[T]
+	size   int
+	lessFn func(a, b T) bool
+	stable bool
+}
+
+// Initialize an empty Pairing Heap, less reports whether a comes out before b, it may be
+// nil when WithComparator is given
+func InitPairing[T any](less func(a, b T) bool, opts ...Option[T]) *PairingHeap[T] {
+	o := buildOptions(less, opts)
+
+	return &PairingHeap[T]{lessFn: o.less, stable: o.stable}
+}
+
+// Value returns the value held by the node
+func (n *PairingNode[T]) Value() T {
+	return n.value
+}
+
+func (ph *PairingHeap[T]) Size() int {
+	return ph.size
+}
+
+func (ph *PairingHeap[T]) IsEmpty() bool {
+	return ph.size == 0
+}
+
+// Add element into Heap and return its node, O(1)
+func (ph *PairingHeap[T]) Enqueue(element T) *PairingNode[T] {
+	n := &PairingNode[T]{value: element, seq: meldSequence.Add(1)}
+
+	ph.root = ph.link(ph.root, n)
+	ph.size++
+
+	return n
+}
+
+func (ph *PairingHeap[T]) Peek() (T, error) {
+	if ph.IsEmpty() {
+		var zero T
+		return zero, errors.New("priority queue is empty")
+	}
+
+	return ph.root.value, nil
+}
+
+// Dequeue removes the element that comes out first, O(log n) amortized
+func (ph *PairingHeap[T]) Dequeue() (T, error) {
+	if ph.IsEmpty() {
+		var zero T
+		return zero, errors.New("priority queue is empty")
+	}
+
+	removed := ph.root
+	ph.root = ph.mergePairs(removed.child)
+	ph.size--
+
+	if ph.root != nil {
+		ph.root.prev = nil
+	}
+
+	removed.child = nil
+	removed.removed = true
+
+	return removed.value, nil
+}
+
+// DecreaseKey replaces the value of a node of this heap with one that does not come out
+// after it, O(1)
+func (ph *PairingHeap[T]) DecreaseKey(n *PairingNode[T], value T) error {
+	if n.removed {
+		return errors.New("node not in heap")
+	}
+
+	if ph.lessFn(n.value, value) {
+		return errors.New("value comes out after the current value")
+	}
+
+	n.value = value
+
+	if n == ph.root {
+		return nil
+	}
+
+	// Cut the subtree of n out of its parent, then link it back at the root
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+
+	n.sibling = nil
+	n.prev = nil
+	ph.root = ph.link(ph.root, n)
+
+	return nil
+}
+
+// Meld moves every element of other into this heap, leaving other empty, both heaps must
+// use the same ordering, O(1)
+func (ph *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if other == ph {
+		return
+	}
+
+	ph.root = ph.link(ph.root, other.root)
+	ph.size += other.size
+
+	other.root = nil
+	other.size = 0
+}
+
+// before reports whether node a comes out strictly before node b
+func (ph *PairingHeap[T]) before(a, b *PairingNode[T]) bool {
+	if ph.lessFn(a.value, b.value) {
+		return true
+	}
+
+	if ph.stable && !ph.lessFn(b.value, a.value) {
+		return a.seq < b.seq
+	}
+
+	return false
+}
+
+// link makes the root that comes out later the first child of the other, returning the new root
+func (ph *PairingHeap[T]) link(a, b *PairingNode[T]) *PairingNode[T] {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	if ph.before(b, a) {
+		a, b = b, a
+	}
+
+	b.prev = a
+	b.sibling = a.child
+
+	if a.child != nil {
+		a.child.prev = b
+	}
+
+	a.child = b
+
+	return a
+}
+
+// mergePairs links the siblings in pairs from left to right, then links the pairs from
+// right to left into a single tree
+func (ph *PairingHeap[T]) mergePairs(first *PairingNode[T]) *PairingNode[T] {
+	var pairs []*PairingNode[T]
+
+	for first != nil {
+		a := first
+		b := a.sibling
+		first = nil
+
+		if b != nil {
+			first = b.sibling
+			b.sibling = nil
+		}
+
+		a.sibling = nil
+		pairs = append(pairs, ph.link(a, b))
+	}
+
+	var result *PairingNode[T]
+
+	for i := len(pairs) - 1; i >= 0; i-- {
+		result = ph.link(pairs[i], result)
+	}
+
+	return result
+}
@@ -1,6 +1,7 @@
 package priorityqueue
 
 import (
+	"cmp"
 	"errors"
 )
 
@@ -8,266 +9,425 @@ const (
 	capacity = 10
 )
 
-// PriorityQueue represents Priority Queue data structure that holds a heap
-// and a map that holds values as key and list of indexes as values
-type PriorityQueue struct {
-	heap         []int
-	hashMap      map[int][]int
+// PriorityQueue represents Priority Queue data structure that holds a heap ordered by a
+// less function, and a map that holds item IDs as key and list of slots as values.
+// A slot holds the heap index of one element and follows it through the heap, so
+// sifting never touches the map. Without an id function there is no map and no slots.
+// In stable mode seq holds the insertion sequence of every heap element to break ties.
+// Every node of the heap has up to arity children
+type PriorityQueue[T any, K comparable] struct {
+	heap         []T
+	hashMap      map[K][]int
+	slots        []int
+	slotOf       []int
+	freeSlots    []int
 	heapSize     int
 	heapCapacity int
+	lessFn       func(a, b T) bool
+	id           func(item T) K
+	stable       bool
+	seq          []uint64
+	nextSeq      uint64
+	arity        int
 }
 
-// Initialize Priority Queue, and heapify so it satisfy Heap Invariant
-func Init(items []int) *PriorityQueue {
-
-	result := &PriorityQueue{heap: make([]int, 0, max(len(items), capacity)), hashMap: make(map[int][]int)}
+// Initialize Priority Queue, and heapify so it satisfy Heap Invariant. less reports
+// whether a comes out before b, it may be nil when WithComparator is given, id returns
+// the ID used by Contain and Remove, it may be nil when they are not needed, then no ID
+// index is kept and Contain and Remove find nothing
+func Init[T any, K comparable](items []T, less func(a, b T) bool, id func(item T) K, opts ...Option[T]) *PriorityQueue[T, K] {
+
+	o := buildOptions(less, opts)
+
+	result := &PriorityQueue[T, K]{
+		heap:    make([]T, 0, max(len(items), capacity)),
+		hashMap: make(map[K][]int),
+		lessFn:  o.less,
+		id:      id,
+		stable:  o.stable,
+		arity:   o.arity,
+	}
 	result.heapSize = len(items)
 	result.heapCapacity = cap(result.heap)
 
 	for i, v := range items {
 		result.heap = append(result.heap, v)
-		result.mapAdd(v, i)
+
+		if id != nil {
+			result.mapAdd(id(v), i)
+		}
+
+		if result.stable {
+			result.seq = append(result.seq, result.nextSeq)
+			result.nextSeq++
+		}
 	}
 
 	// Heapify Process, O(n)
-	for i := max(0, len(result.heap)); i >= 0; i-- {
+	for i := len(result.heap) / result.arity; i >= 0; i-- {
 		result.bubbleDown(i)
 	}
 
 	return result
 }
 
-func (pq *PriorityQueue) Size() int {
+// InitOrdered initializes a min Priority Queue of ordered values, or a max Priority Queue
+// with WithMaxHeap, every value is its own ID
+func InitOrdered[T cmp.Ordered](items []T, opts ...Option[T]) *PriorityQueue[T, T] {
+	return Init(items, cmp.Less[T], Identity[T], opts...)
+}
+
+// Identity returns item, for items that are their own ID
+func Identity[T comparable](item T) T {
+	return item
+}
+
+func (pq *PriorityQueue[T, K]) Size() int {
 	return pq.heapSize
 }
 
-func (pq *PriorityQueue) Clear() {
+func (pq *PriorityQueue[T, K]) Clear() {
 
-	pq.heap = make([]int, 0, capacity)
-	pq.hashMap = make(map[int][]int)
+	pq.heap = make([]T, 0, capacity)
+	pq.hashMap = make(map[K][]int)
+	pq.slots = nil
+	pq.slotOf = nil
+	pq.freeSlots = nil
 	pq.heapSize = 0
 	pq.heapCapacity = capacity
+	pq.seq = nil
+	pq.nextSeq = 0
 }
 
-func (pq *PriorityQueue) IsEmpty() bool {
+func (pq *PriorityQueue[T, K]) IsEmpty() bool {
 	return pq.heapSize == 0
 }
 
-// Check if element is in Heap, O(1)
-func (pq *PriorityQueue) Contain(element int) (bool, error) {
+// Check if an item with the given ID is in Heap, O(1)
+func (pq *PriorityQueue[T, K]) Contain(id K) (bool, error) {
 	if pq.IsEmpty() {
 		return false, errors.New("priority queue is empty")
 	}
 
-	_, ok := pq.hashMap[element]
+	_, ok := pq.hashMap[id]
 
 	return ok, nil
 }
 
-func (pq *PriorityQueue) Peek() (int, error) {
+func (pq *PriorityQueue[T, K]) Peek() (T, error) {
 	if pq.IsEmpty() {
-		return 0, errors.New("priority queue is empty")
+		var zero T
+		return zero, errors.New("priority queue is empty")
 	}
 
 	return pq.heap[0], nil
 }
 
-func (pq *PriorityQueue) Dequeue() (int, error) {
+func (pq *PriorityQueue[T, K]) Dequeue() (T, error) {
 	return pq.RemoveAt(0)
 }
 
-// Add element into Heap, O(log n), O(n) if resizing occurs
-func (pq *PriorityQueue) Enqueue(element int) {
+// Add element into Heap, O(log n), the heap doubles when full so resizing is amortized O(1)
+func (pq *PriorityQueue[T, K]) Enqueue(element T) {
 
-	if pq.heapSize < cap(pq.heap) {
-		pq.heap = append(pq.heap, element)
-	} else {
-		pq.heap = append(pq.heap, element)
-		temp := make([]int, 0, pq.heapCapacity+10)
-		temp = append(temp, pq.heap...)
-		pq.heap = temp
-		pq.heapCapacity = cap(pq.heap)
+	pq.heap = append(pq.heap, element)
+	pq.heapCapacity = cap(pq.heap)
+
+	if pq.id != nil {
+		pq.mapAdd(pq.id(element), pq.heapSize)
+	}
+
+	if pq.stable {
+		pq.seq = append(pq.seq, pq.nextSeq)
+		pq.nextSeq++
 	}
 
-	pq.mapAdd(element, pq.heapSize)
 	pq.floatUp(pq.heapSize)
 	pq.heapSize++
 }
 
-// RemoveAt method, removes element based on index, O(log n), O(n) if resizing occurs
-func (pq *PriorityQueue) RemoveAt(index int) (int, error) {
+// RemoveAt method, removes element based on index, O(log n), the heap halves once it is
+// a quarter full so resizing is amortized O(1)
+func (pq *PriorityQueue[T, K]) RemoveAt(index int) (T, error) {
 	if pq.IsEmpty() {
-		return 0, errors.New("priority queue is empty")
+		var zero T
+		return zero, errors.New("priority queue is empty")
 	}
 
 	pq.heapSize--
 	removedData := pq.heap[index]
+	removedSlot := pq.slotAt(index)
 	pq.swap(index, pq.heapSize)
+
+	var zero T
+	pq.heap[pq.heapSize] = zero
 	pq.heap = pq.heap[:pq.heapSize]
 
-	if pq.heapSize < pq.heapCapacity-10 {
-		temp := make([]int, 0, pq.heapCapacity-10)
-		temp = append(temp, pq.heap...)
+	if pq.id != nil {
+		pq.slotOf = pq.slotOf[:pq.heapSize]
+	}
+
+	if pq.stable {
+		pq.seq = pq.seq[:pq.heapSize]
+	}
+
+	if pq.heapCapacity > capacity && pq.heapSize < pq.heapCapacity/4 {
+		temp := make([]T, pq.heapSize, max(pq.heapCapacity/2, capacity))
+		copy(temp, pq.heap)
 		pq.heap = temp
 		pq.heapCapacity = cap(pq.heap)
 	}
 
-	pq.mapRemove(removedData, pq.heapSize)
+	if pq.id != nil {
+		pq.mapRemove(pq.id(removedData), removedSlot)
+	}
 
 	if index == pq.heapSize {
 		return removedData, nil
 	}
 
-	element := pq.heap[index]
-	pq.bubbleDown(index)
-
-	if pq.heap[index] == element {
+	// Nothing moved down, so the element may have to move up instead
+	if pq.bubbleDown(index) == index {
 		pq.floatUp(index)
 	}
 
 	return removedData, nil
 }
 
-// Remove method, removes specified element in Heap, O(log n)
-func (pq *PriorityQueue) Remove(element int) (int, error) {
+// Remove method, removes the item with the given ID from Heap, O(log n)
+func (pq *PriorityQueue[T, K]) Remove(id K) (T, error) {
 
-	index, err := pq.mapGet(element)
+	index, err := pq.mapGet(id)
 	if err == nil {
 		return pq.RemoveAt(index)
 	} else {
-		return 0, err
+		var zero T
+		return zero, err
 	}
 }
 
-// Check if Heap Invariant is satisfied
-func (pq *PriorityQueue) IsMinHeap(index int) bool {
+// Check if Heap Invariant is satisfied for the subtree at index under the queue ordering,
+// no child comes out strictly before its parent
+func (pq *PriorityQueue[T, K]) IsHeap(index int) bool {
 
 	if index >= pq.heapSize {
 		return true
 	}
 
-	leftChild := index*2 + 1
-	rightChild := index*2 + 1
+	firstChild := index*pq.arity + 1
 
-	if leftChild < pq.heapSize && !pq.less(index, leftChild) {
-		return false
-	}
-	if rightChild < pq.heapSize && !pq.less(index, rightChild) {
-		return false
+	for child := firstChild; child < min(firstChild+pq.arity, pq.heapSize); child++ {
+		if !pq.less(index, child) || !pq.IsHeap(child) {
+			return false
+		}
 	}
 
-	return pq.IsMinHeap(leftChild) && pq.IsMinHeap(rightChild)
+	return true
 }
 
-// Add element into Hash Map, if duplicate is found, ignore
-func (pq *PriorityQueue) mapAdd(key int, index int) {
-
-	if pq.hashMap[key] == nil {
-		pq.hashMap[key] = append(pq.hashMap[key], index)
-	} else {
-		duplicate := false
+// Check if Heap Invariant is satisfied
+//
+// Deprecated: use IsHeap, which checks the invariant for any ordering
+func (pq *PriorityQueue[T, K]) IsMinHeap(index int) bool {
+	return pq.IsHeap(index)
+}
 
-		for _, i := range pq.hashMap[key] {
-			if i == index {
-				duplicate = true
-			}
-		}
+// mapAdd gives the element appended at index a slot and files the slot under the item ID,
+// a freed slot is reused before a new one is made
+func (pq *PriorityQueue[T, K]) mapAdd(key K, index int) {
+	var slot int
 
-		if !duplicate {
-			pq.hashMap[key] = append(pq.hashMap[key], index)
-		}
+	if n := len(pq.freeSlots); n > 0 {
+		slot = pq.freeSlots[n-1]
+		pq.freeSlots = pq.freeSlots[:n-1]
+		pq.slots[slot] = index
+	} else {
+		slot = len(pq.slots)
+		pq.slots = append(pq.slots, index)
 	}
+
+	pq.slotOf = append(pq.slotOf, slot)
+	pq.hashMap[key] = append(pq.hashMap[key], slot)
 }
 
-func (pq *PriorityQueue) mapGet(element int) (int, error) {
+// mapGet returns the heap index of the item with the given ID enqueued last
+func (pq *PriorityQueue[T, K]) mapGet(id K) (int, error) {
 
-	if _, ok := pq.hashMap[element]; !ok {
+	slots, ok := pq.hashMap[id]
+	if !ok {
 		return 0, errors.New("element not found")
 	}
 
-	index := pq.hashMap[element][len(pq.hashMap[element])-1]
-	return index, nil
+	return pq.slots[slots[len(slots)-1]], nil
 }
 
-func (pq *PriorityQueue) floatUp(index int) {
+// mapRemove drops slot from the item ID and frees it
+func (pq *PriorityQueue[T, K]) mapRemove(key K, slot int) {
 
-	if index == 0 {
-		return
+	for i, s := range pq.hashMap[key] {
+		if s == slot {
+			pq.hashMap[key] = append(pq.hashMap[key][:i], pq.hashMap[key][i+1:]...)
+			break
+		}
 	}
 
-	parent := (index - 1) / 2
-
-	if pq.less(index, parent) {
-		pq.swap(index, parent)
-		pq.floatUp(parent)
+	if len(pq.hashMap[key]) == 0 {
+		delete(pq.hashMap, key)
 	}
+
+	pq.freeSlots = append(pq.freeSlots, slot)
 }
 
-// bubbleDown method, element bubble down to satisfy Heap Invariant
-func (pq *PriorityQueue) bubbleDown(index int) {
+// floatUp moves the element at index up by shifting every parent that comes out after
+// it one level down into the hole, then drops the element into the hole, returning its
+// new index, O(log n)
+func (pq *PriorityQueue[T, K]) floatUp(index int) int {
+	element, seq, slot := pq.heap[index], pq.seqAt(index), pq.slotAt(index)
 
-	leftChild := index*2 + 1
-	rightChild := index*2 + 2
-	smallest := index
+	for index > 0 {
+		parent := (index - 1) / pq.arity
 
-	if leftChild < len(pq.heap) && pq.less(leftChild, smallest) {
-		smallest = leftChild
+		if !pq.before(element, seq, pq.heap[parent], pq.seqAt(parent)) {
+			break
+		}
+
+		pq.move(parent, index)
+		index = parent
 	}
-	if rightChild < len(pq.heap) && pq.less(rightChild, smallest) {
-		smallest = rightChild
+
+	pq.place(element, seq, slot, index)
+
+	return index
+}
+
+// bubbleDown moves the element at index down by shifting the child that comes out first
+// one level up into the hole while it comes out before the element, then drops the
+// element into the hole, returning its new index, O(d log n)
+func (pq *PriorityQueue[T, K]) bubbleDown(index int) int {
+	if index >= len(pq.heap) {
+		return index
 	}
 
-	if smallest != index {
-		pq.swap(smallest, index)
-		pq.bubbleDown(smallest)
+	element, seq, slot := pq.heap[index], pq.seqAt(index), pq.slotAt(index)
+
+	for {
+		firstChild := index*pq.arity + 1
+		if firstChild >= len(pq.heap) {
+			break
+		}
+
+		smallest := firstChild
+		lastChild := min(firstChild+pq.arity, len(pq.heap))
+
+		for child := firstChild + 1; child < lastChild; child++ {
+			if pq.before(pq.heap[child], pq.seqAt(child), pq.heap[smallest], pq.seqAt(smallest)) {
+				smallest = child
+			}
+		}
+
+		if !pq.before(pq.heap[smallest], pq.seqAt(smallest), element, seq) {
+			break
+		}
+
+		pq.move(smallest, index)
+		index = smallest
 	}
+
+	pq.place(element, seq, slot, index)
+
+	return index
 }
 
-// swap method, swap places of two elements in Heap
-func (pq *PriorityQueue) swap(i int, j int) {
-	iElement := pq.heap[i]
-	jElement := pq.heap[j]
+// move copies the element at from into the hole at to, along with its slot
+func (pq *PriorityQueue[T, K]) move(from int, to int) {
+	pq.heap[to] = pq.heap[from]
+
+	if pq.stable {
+		pq.seq[to] = pq.seq[from]
+	}
 
-	pq.heap[i], pq.heap[j] = jElement, iElement
-	pq.mapSwap(iElement, jElement, i, j)
+	if pq.id != nil {
+		pq.slotOf[to] = pq.slotOf[from]
+		pq.slots[pq.slotOf[to]] = to
+	}
 }
 
-func (pq *PriorityQueue) mapRemove(element int, idx int) {
+// place drops an element held out of Heap into the hole at index
+func (pq *PriorityQueue[T, K]) place(element T, seq uint64, slot int, index int) {
+	pq.heap[index] = element
 
-	for index, value := range pq.hashMap[element] {
-		if value == idx {
-			pq.hashMap[element] = append(pq.hashMap[element][:index], pq.hashMap[element][index+1:]...)
-		}
+	if pq.stable {
+		pq.seq[index] = seq
 	}
 
-	if len(pq.hashMap[element]) == 0 {
-		delete(pq.hashMap, element)
+	if pq.id != nil {
+		pq.slotOf[index] = slot
+		pq.slots[slot] = index
 	}
 }
 
-// mapSwap method, swap indexes (value) mapped to values (key) when swapping occurs
-func (pq *PriorityQueue) mapSwap(element1 int, element2 int, index1 int, index2 int) {
+// before reports whether element a comes out strictly before element b, in stable mode
+// equal elements are ordered by insertion sequence
+func (pq *PriorityQueue[T, K]) before(a T, aSeq uint64, b T, bSeq uint64) bool {
+	if pq.lessFn(a, b) {
+		return true
+	}
 
-	for idx, index := range pq.hashMap[element1] {
-		if index == index1 {
-			pq.hashMap[element1] = append(pq.hashMap[element1][:idx], pq.hashMap[element1][idx+1:]...)
-		}
+	return pq.stable && !pq.lessFn(b, a) && aSeq < bSeq
+}
+
+// seqAt returns the insertion sequence of the element at index, 0 outside stable mode
+func (pq *PriorityQueue[T, K]) seqAt(index int) uint64 {
+	if !pq.stable {
+		return 0
 	}
 
-	for idx, index := range pq.hashMap[element2] {
-		if index == index2 {
-			pq.hashMap[element2] = append(pq.hashMap[element2][:idx], pq.hashMap[element2][idx+1:]...)
-		}
+	return pq.seq[index]
+}
+
+// slotAt returns the slot of the element at index, 0 without an ID index
+func (pq *PriorityQueue[T, K]) slotAt(index int) int {
+	if pq.id == nil {
+		return 0
+	}
+
+	return pq.slotOf[index]
+}
+
+// swap method, swap places of two elements in Heap, their slots follow them
+func (pq *PriorityQueue[T, K]) swap(i int, j int) {
+	if i == j {
+		return
 	}
 
-	pq.hashMap[element1] = append(pq.hashMap[element1], index2)
-	pq.hashMap[element2] = append(pq.hashMap[element2], index1)
+	pq.heap[i], pq.heap[j] = pq.heap[j], pq.heap[i]
+
+	if pq.stable {
+		pq.seq[i], pq.seq[j] = pq.seq[j], pq.seq[i]
+	}
+
+	if pq.id != nil {
+		pq.slotOf[i], pq.slotOf[j] = pq.slotOf[j], pq.slotOf[i]
+		pq.slots[pq.slotOf[i]] = i
+		pq.slots[pq.slotOf[j]] = j
+	}
 }
 
-func (pq *PriorityQueue) less(i int, j int) bool {
+// less checks if the element at i may sit above the element at j, equal elements included,
+// in stable mode equal elements are ordered by insertion sequence
+func (pq *PriorityQueue[T, K]) less(i int, j int) bool {
 	node1 := pq.heap[i]
 	node2 := pq.heap[j]
-	return compareTo(node1, node2) <= 0
+
+	if pq.lessFn(node2, node1) {
+		return false
+	}
+
+	if pq.stable && !pq.lessFn(node1, node2) {
+		return pq.seq[i] <= pq.seq[j]
+	}
+
+	return true
 }
 
 func max(x int, y int) int {
@@ -277,15 +437,3 @@ func max(x int, y int) int {
 		return x
 	}
 }
-
-func compareTo(i int, j int) int {
-
-	if i < j {
-		return -1
-	} else if i > j {
-		return 1
-	} else {
-		return 0
-	}
-
-}
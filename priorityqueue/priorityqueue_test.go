@@ -0,0 +1,166 @@
+package priorityqueue
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// checkIndex verifies that every element is filed under its ID in a slot holding its index
+func checkIndex[T any, K comparable](t *testing.T, pq *PriorityQueue[T, K]) {
+	t.Helper()
+
+	filed := 0
+
+	for _, slots := range pq.hashMap {
+		filed += len(slots)
+	}
+
+	if filed != pq.heapSize || len(pq.heap) != pq.heapSize || len(pq.slotOf) != pq.heapSize {
+		t.Fatalf("%d slots filed for %d elements", filed, pq.heapSize)
+	}
+
+	for i, element := range pq.heap {
+		slot := pq.slotOf[i]
+
+		if pq.slots[slot] != i || !slices.Contains(pq.hashMap[pq.id(element)], slot) {
+			t.Fatalf("element %v at index %d is not indexed", element, i)
+		}
+	}
+
+	if !pq.IsHeap(0) {
+		t.Fatal("heap invariant broken")
+	}
+}
+
+func TestRemoveAndContainWithDuplicates(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 8))
+
+	for _, arity := range []int{2, 3, 4, 8} {
+		var reference []int
+
+		for i := 0; i < 50; i++ {
+			reference = append(reference, r.IntN(40))
+		}
+
+		pq := InitOrdered(reference, WithArity[int](arity))
+		checkIndex(t, pq)
+
+		for step := 0; step < 3000; step++ {
+			value := r.IntN(40)
+
+			switch r.IntN(4) {
+			case 0:
+				pq.Enqueue(value)
+				reference = append(reference, value)
+			case 1:
+				got, err := pq.Dequeue()
+
+				if len(reference) == 0 {
+					if err == nil {
+						t.Fatalf("arity %d step %d: Dequeue on empty queue returned %d", arity, step, got)
+					}
+
+					continue
+				}
+
+				smallest := slices.Min(reference)
+
+				if err != nil || got != smallest {
+					t.Fatalf("arity %d step %d: Dequeue() = %d, %v, want %d", arity, step, got, err, smallest)
+				}
+
+				reference = slices.Delete(reference, slices.Index(reference, smallest), slices.Index(reference, smallest)+1)
+			case 2:
+				got, err := pq.Remove(value)
+				index := slices.Index(reference, value)
+
+				if (err == nil) != (index >= 0) || (err == nil && got != value) {
+					t.Fatalf("arity %d step %d: Remove(%d) = %d, %v", arity, step, value, got, err)
+				}
+
+				if index >= 0 {
+					reference = slices.Delete(reference, index, index+1)
+				}
+			default:
+				ok, err := pq.Contain(value)
+
+				if len(reference) == 0 {
+					if err == nil {
+						t.Fatalf("arity %d step %d: Contain on empty queue returned no error", arity, step)
+					}
+
+					continue
+				}
+
+				if ok != slices.Contains(reference, value) {
+					t.Fatalf("arity %d step %d: Contain(%d) = %v", arity, step, value, ok)
+				}
+			}
+
+			if pq.Size() != len(reference) {
+				t.Fatalf("arity %d step %d: Size() = %d, want %d", arity, step, pq.Size(), len(reference))
+			}
+
+			checkIndex(t, pq)
+		}
+	}
+}
+
+// The heap grows and shrinks geometrically, so the capacity stays within a constant factor of the size
+func TestCapacityFollowsSize(t *testing.T) {
+	pq := InitOrdered[int](nil)
+
+	for i := 0; i < 10000; i++ {
+		pq.Enqueue(i)
+	}
+
+	checkIndex(t, pq)
+
+	for i := 0; i < 10000; i++ {
+		if value, err := pq.Dequeue(); err != nil || value != i {
+			t.Fatalf("Dequeue() = %d, %v, want %d", value, err, i)
+		}
+
+		if size := pq.Size(); size > capacity && cap(pq.heap) > 4*size {
+			t.Fatalf("capacity %d for %d elements", cap(pq.heap), size)
+		}
+	}
+
+	checkIndex(t, pq)
+}
+
+func TestWithoutIDIndex(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 9))
+	values := make([]int, 500)
+
+	for i := range values {
+		values[i] = r.IntN(50)
+	}
+
+	pq := Init[int, int](values[:250], func(a, b int) bool { return a < b }, nil, WithArity[int](4))
+
+	for _, value := range values[250:] {
+		pq.Enqueue(value)
+	}
+
+	if pq.hashMap == nil || len(pq.hashMap) != 0 || pq.slots != nil || pq.slotOf != nil {
+		t.Fatal("an ID index was kept without an id function")
+	}
+
+	if ok, err := pq.Contain(values[0]); ok || err != nil {
+		t.Fatalf("Contain() = %v, %v, want false", ok, err)
+	}
+
+	if _, err := pq.Remove(values[0]); err == nil {
+		t.Fatal("Remove() found an item without an ID index")
+	}
+
+	slices.Sort(values)
+
+	for _, want := range values {
+		if got, err := pq.Dequeue(); err != nil || got != want {
+			t.Fatalf("Dequeue() = %d, %v, want %d", got, err, want)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// task represents an item whose priority repeats, label records the insertion order
+type task struct {
+	priority int
+	label    int
+}
+
+func taskLess(a, b task) bool {
+	return a.priority < b.priority
+}
+
+func taskLabel(t task) int {
+	return t.label
+}
+
+// fifoOrder returns tasks sorted by priority, equal priorities in insertion order
+func fifoOrder(tasks []task) []task {
+	sorted := slices.Clone(tasks)
+
+	slices.SortStableFunc(sorted, func(a, b task) int {
+		return cmp.Compare(a.priority, b.priority)
+	})
+
+	return sorted
+}
+
+// duplicateHeavy returns n tasks drawn from only three priorities
+func duplicateHeavy(n int) []task {
+	r := rand.New(rand.NewPCG(7, 11))
+	tasks := make([]task, n)
+
+	for i := range tasks {
+		tasks[i] = task{priority: r.IntN(3), label: i}
+	}
+
+	return tasks
+}
+
+func drain(t *testing.T, pq *PriorityQueue[task, int]) []task {
+	t.Helper()
+
+	var out []task
+
+	for !pq.IsEmpty() {
+		if !pq.IsHeap(0) {
+			t.Fatal("heap invariant broken")
+		}
+
+		item, err := pq.Dequeue()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out = append(out, item)
+	}
+
+	return out
+}
+
+func TestStableOrderEnqueue(t *testing.T) {
+	tasks := duplicateHeavy(500)
+
+	for _, arity := range []int{2, 3, 4, 8} {
+		pq := Init(nil, taskLess, taskLabel, WithStableOrder[task](), WithArity[task](arity))
+
+		for _, item := range tasks {
+			pq.Enqueue(item)
+		}
+
+		if got, want := drain(t, pq), fifoOrder(tasks); !slices.Equal(got, want) {
+			t.Errorf("arity %d: equal priorities did not come out in insertion order\ngot  %v\nwant %v", arity, got, want)
+		}
+	}
+}
+
+func TestStableOrderHeapify(t *testing.T) {
+	tasks := duplicateHeavy(500)
+	pq := Init(tasks, taskLess, taskLabel, WithStableOrder[task]())
+
+	if got, want := drain(t, pq), fifoOrder(tasks); !slices.Equal(got, want) {
+		t.Errorf("equal priorities did not come out in the order of items\ngot  %v\nwant %v", got, want)
+	}
+}
+
+func TestStableOrderMaxHeap(t *testing.T) {
+	tasks := duplicateHeavy(200)
+	pq := Init(tasks, taskLess, taskLabel, WithStableOrder[task](), WithMaxHeap[task]())
+
+	want := slices.Clone(tasks)
+	slices.SortStableFunc(want, func(a, b task) int {
+		return cmp.Compare(b.priority, a.priority)
+	})
+
+	if got := drain(t, pq); !slices.Equal(got, want) {
+		t.Errorf("equal priorities did not come out in insertion order\ngot  %v\nwant %v", got, want)
+	}
+}
+
+func TestStableOrderRemove(t *testing.T) {
+	tasks := duplicateHeavy(300)
+	pq := Init(nil, taskLess, taskLabel, WithStableOrder[task]())
+
+	for _, item := range tasks {
+		pq.Enqueue(item)
+	}
+
+	var kept []task
+
+	for _, item := range tasks {
+		if item.label%3 != 0 {
+			kept = append(kept, item)
+			continue
+		}
+
+		removed, err := pq.Remove(item.label)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if removed != item {
+			t.Fatalf("Remove(%d) = %v, want %v", item.label, removed, item)
+		}
+	}
+
+	// Items enqueued after the removals still come out behind equal earlier ones
+	for i := 0; i < 30; i++ {
+		item := task{priority: i % 3, label: len(tasks) + i}
+		pq.Enqueue(item)
+		kept = append(kept, item)
+	}
+
+	if got, want := drain(t, pq), fifoOrder(kept); !slices.Equal(got, want) {
+		t.Errorf("equal priorities did not come out in insertion order after Remove\ngot  %v\nwant %v", got, want)
+	}
+}
+
+func TestStableOrderInterleaved(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 5))
+	pq := Init(nil, taskLess, taskLabel, WithStableOrder[task](), WithArity[task](4))
+
+	// pending mirrors the queue, sorted so its first task is the one that comes out next
+	var pending []task
+
+	for label := 0; label < 2000; label++ {
+		if r.IntN(3) == 0 && len(pending) > 0 {
+			item, err := pq.Dequeue()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if item != pending[0] {
+				t.Fatalf("step %d: Dequeue() = %v, want %v", label, item, pending[0])
+			}
+
+			pending = pending[1:]
+
+			continue
+		}
+
+		item := task{priority: r.IntN(2), label: label}
+		pq.Enqueue(item)
+		pending = fifoOrder(append(pending, item))
+	}
+
+	if got := drain(t, pq); !slices.Equal(got, pending) {
+		t.Errorf("equal priorities did not come out in insertion order\ngot  %v\nwant %v", got, pending)
+	}
+}
+
+// indexedEntry records what an Indexed Priority Queue is expected to hold
+type indexedEntry struct {
+	handle   Handle
+	priority int
+}
+
+func drainIndexed(t *testing.T, pq *IndexedPriorityQueue[Handle, int]) []Handle {
+	t.Helper()
+
+	var out []Handle
+
+	for !pq.IsEmpty() {
+		if !pq.IsHeap(0) {
+			t.Fatal("heap invariant broken")
+		}
+
+		handle, _, err := pq.Dequeue()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out = append(out, handle)
+	}
+
+	return out
+}
+
+// expectedHandles returns the handles sorted by priority, equal priorities in enqueue order
+func expectedHandles(entries map[Handle]int) []Handle {
+	var sorted []indexedEntry
+
+	for handle, priority := range entries {
+		sorted = append(sorted, indexedEntry{handle, priority})
+	}
+
+	slices.SortFunc(sorted, func(a, b indexedEntry) int {
+		return cmp.Or(cmp.Compare(a.priority, b.priority), cmp.Compare(a.handle, b.handle))
+	})
+
+	handles := make([]Handle, len(sorted))
+
+	for i, entry := range sorted {
+		handles[i] = entry.handle
+	}
+
+	return handles
+}
+
+// enqueueHandles fills pq with n duplicate-heavy priorities, every value is its own handle
+func enqueueHandles(t *testing.T, pq *IndexedPriorityQueue[Handle, int], n int) map[Handle]int {
+	t.Helper()
+
+	entries := map[Handle]int{}
+
+	for i := 0; i < n; i++ {
+		priority := i * 7 % 3
+		handle := pq.Enqueue(Handle(i+1), priority)
+
+		if handle != Handle(i+1) {
+			t.Fatalf("Enqueue returned handle %d, want %d", handle, i+1)
+		}
+
+		entries[handle] = priority
+	}
+
+	return entries
+}
+
+func TestIndexedStableOrder(t *testing.T) {
+	for _, arity := range []int{2, 4, 8} {
+		pq := InitIndexedOrdered[Handle](WithStableOrder[int](), WithArity[int](arity))
+		entries := enqueueHandles(t, pq, 400)
+
+		if got, want := drainIndexed(t, pq), expectedHandles(entries); !slices.Equal(got, want) {
+			t.Errorf("arity %d: equal priorities did not come out in enqueue order\ngot  %v\nwant %v", arity, got, want)
+		}
+	}
+}
+
+func TestIndexedStableOrderRemove(t *testing.T) {
+	pq := InitIndexedOrdered[Handle](WithStableOrder[int]())
+	entries := enqueueHandles(t, pq, 400)
+
+	for handle := range entries {
+		if handle%4 != 0 {
+			continue
+		}
+
+		if _, _, err := pq.Remove(handle); err != nil {
+			t.Fatal(err)
+		}
+
+		delete(entries, handle)
+	}
+
+	if got, want := drainIndexed(t, pq), expectedHandles(entries); !slices.Equal(got, want) {
+		t.Errorf("equal priorities did not come out in enqueue order after Remove\ngot  %v\nwant %v", got, want)
+	}
+}
+
+// An item moved among equal priorities keeps its enqueue order there, not the order of updates
+func TestIndexedStableOrderUpdatePriority(t *testing.T) {
+	pq := InitIndexedOrdered[Handle](WithStableOrder[int]())
+	entries := enqueueHandles(t, pq, 400)
+
+	// Update from the last handle down, so update order is the reverse of enqueue order
+	for handle := Handle(400); handle > 0; handle-- {
+		var priority int
+
+		switch handle % 5 {
+		case 0:
+			priority = 1
+		case 1:
+			priority = entries[handle]
+		default:
+			continue
+		}
+
+		if err := pq.UpdatePriority(handle, priority); err != nil {
+			t.Fatal(err)
+		}
+
+		entries[handle] = priority
+	}
+
+	for handle := Handle(1); handle <= 400; handle += 7 {
+		if err := pq.DecreaseKey(handle, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		entries[handle] = 0
+	}
+
+	if got, want := drainIndexed(t, pq), expectedHandles(entries); !slices.Equal(got, want) {
+		t.Errorf("equal priorities did not come out in enqueue order after UpdatePriority\ngot  %v\nwant %v", got, want)
+	}
+}
@@ -0,0 +1,199 @@
+package splaytree
+
+import (
+	"cmp"
+
+	"github.com/seonicklaus/data-structures-go/binarysearchtree"
+	"github.com/seonicklaus/data-structures-go/internal/traversal"
+)
+
+type node struct {
+	data  int
+	left  *node
+	right *node
+}
+
+// SplayTree represents a self-adjusting Binary Search Tree, every access moves the
+// touched element to the root so recently used elements stay cheap to reach
+type SplayTree struct {
+	root      *node
+	nodeCount uint
+}
+
+var _ binarysearchtree.OrderedSet = (*SplayTree)(nil)
+
+func (t *SplayTree) Size() uint {
+	return t.nodeCount
+}
+
+func (t *SplayTree) IsEmpty() bool {
+	return t.nodeCount == 0
+}
+
+func (t *SplayTree) GetHeight() int {
+	return height(t.root)
+}
+
+// Check if element is in tree and splay it (or its last visited neighbour) to the root, O(log n) amortized
+func (t *SplayTree) Contains(element int) bool {
+	if t.root == nil {
+		return false
+	}
+
+	t.root = splay(t.root, element)
+
+	return t.root.data == element
+}
+
+// Add element into tree as the new root, O(log n) amortized
+func (t *SplayTree) Add(element int) bool {
+	if t.root == nil {
+		t.root = &node{data: element}
+		t.nodeCount++
+		return true
+	}
+
+	t.root = splay(t.root, element)
+	direction := cmp.Compare(element, t.root.data)
+
+	if direction == 0 {
+		return false
+	}
+
+	newRoot := &node{data: element}
+
+	if direction < 0 {
+		newRoot.left = t.root.left
+		newRoot.right = t.root
+		t.root.left = nil
+	} else {
+		newRoot.right = t.root.right
+		newRoot.left = t.root
+		t.root.right = nil
+	}
+
+	t.root = newRoot
+	t.nodeCount++
+
+	return true
+}
+
+// Remove element from tree, O(log n) amortized
+func (t *SplayTree) Remove(element int) bool {
+	if !t.Contains(element) {
+		return false
+	}
+
+	removed := t.root
+
+	if removed.left == nil {
+		t.root = removed.right
+	} else {
+		// element is greater than everything on the left, so splaying for it brings up the largest
+		t.root = splay(removed.left, element)
+		t.root.right = removed.right
+	}
+
+	removed.left = nil
+	removed.right = nil
+	t.nodeCount--
+
+	return true
+}
+
+func (t *SplayTree) PrintTree(order string) ([]int, error) {
+	return traversal.PrintTree(t.root, order)
+}
+
+// splay moves element, or the last node visited while searching for it, to the root
+// using top-down zig, zig-zig and zig-zag steps
+func splay(root *node, element int) *node {
+	header := &node{}
+	leftMax := header
+	rightMin := header
+	t := root
+
+	for {
+		direction := cmp.Compare(element, t.data)
+
+		if direction < 0 {
+			if t.left == nil {
+				break
+			}
+
+			if cmp.Compare(element, t.left.data) < 0 {
+				t = rotateRight(t)
+
+				if t.left == nil {
+					break
+				}
+			}
+
+			rightMin.left = t
+			rightMin = t
+			t = t.left
+		} else if direction > 0 {
+			if t.right == nil {
+				break
+			}
+
+			if cmp.Compare(element, t.right.data) > 0 {
+				t = rotateLeft(t)
+
+				if t.right == nil {
+					break
+				}
+			}
+
+			leftMax.right = t
+			leftMax = t
+			t = t.right
+		} else {
+			break
+		}
+	}
+
+	leftMax.right = t.left
+	rightMin.left = t.right
+	t.left = header.right
+	t.right = header.left
+
+	return t
+}
+
+func rotateLeft(n *node) *node {
+	r := n.right
+	n.right = r.left
+	r.left = n
+
+	return r
+}
+
+func rotateRight(n *node) *node {
+	l := n.left
+	n.left = l.right
+	l.right = n
+
+	return l
+}
+
+func height(n *node) int {
+	if n == nil {
+		return 0
+	}
+
+	return max(height(n.left), height(n.right)) + 1
+}
+
+// Value and Children let the traversal package walk the tree
+func (n *node) Value() int {
+	return n.data
+}
+
+func (n *node) Children() (*node, *node) {
+	return n.left, n.right
+}
+
+func NewTree() *SplayTree {
+	return &SplayTree{}
+}
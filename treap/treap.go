@@ -0,0 +1,189 @@
+package treap
+
+import (
+	"cmp"
+	"math/rand/v2"
+
+	"github.com/seonicklaus/data-structures-go/binarysearchtree"
+	"github.com/seonicklaus/data-structures-go/internal/traversal"
+)
+
+// node represents a Treap node, it is ordered as a Binary Search Tree by data
+// and as a max heap by a random priority
+type node struct {
+	data     int
+	priority uint64
+	left     *node
+	right    *node
+}
+
+// Treap represents a randomized Binary Search Tree with expected O(log n) height
+// regardless of insertion order
+type Treap struct {
+	root      *node
+	nodeCount uint
+}
+
+var _ binarysearchtree.OrderedSet = (*Treap)(nil)
+
+func (t *Treap) Size() uint {
+	return t.nodeCount
+}
+
+func (t *Treap) IsEmpty() bool {
+	return t.nodeCount == 0
+}
+
+func (t *Treap) GetHeight() int {
+	return height(t.root)
+}
+
+// Check if element is in Treap, expected O(log n)
+func (t *Treap) Contains(element int) bool {
+	n := t.root
+
+	for n != nil {
+		direction := cmp.Compare(element, n.data)
+
+		if direction == 0 {
+			return true
+		}
+
+		if direction < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return false
+}
+
+// Add element into Treap, expected O(log n)
+func (t *Treap) Add(element int) bool {
+	if t.Contains(element) {
+		return false
+	}
+
+	t.root = t.add(t.root, element)
+	t.nodeCount++
+
+	return true
+}
+
+// Remove element from Treap, expected O(log n)
+func (t *Treap) Remove(element int) bool {
+	if !t.Contains(element) {
+		return false
+	}
+
+	t.root = t.remove(t.root, element)
+	t.nodeCount--
+
+	return true
+}
+
+func (t *Treap) PrintTree(order string) ([]int, error) {
+	return traversal.PrintTree(t.root, order)
+}
+
+// add inserts element as a leaf, then rotates it up while its priority beats its parent's
+func (t *Treap) add(n *node, element int) *node {
+	if n == nil {
+		return &node{data: element, priority: rand.Uint64()}
+	}
+
+	if cmp.Compare(element, n.data) > 0 {
+		n.right = t.add(n.right, element)
+
+		if n.right.priority > n.priority {
+			n = rotateLeft(n)
+		}
+	} else {
+		n.left = t.add(n.left, element)
+
+		if n.left.priority > n.priority {
+			n = rotateRight(n)
+		}
+	}
+
+	return n
+}
+
+// remove rotates the node holding element down, promoting its higher priority child, until it is a leaf
+func (t *Treap) remove(n *node, element int) *node {
+	if n == nil {
+		return nil
+	}
+
+	direction := cmp.Compare(element, n.data)
+
+	if direction < 0 {
+		n.left = t.remove(n.left, element)
+		return n
+	}
+
+	if direction > 0 {
+		n.right = t.remove(n.right, element)
+		return n
+	}
+
+	if n.left == nil {
+		rightChild := n.right
+		n.right = nil
+		return rightChild
+	}
+
+	if n.right == nil {
+		leftChild := n.left
+		n.left = nil
+		return leftChild
+	}
+
+	if n.left.priority > n.right.priority {
+		n = rotateRight(n)
+		n.right = t.remove(n.right, element)
+	} else {
+		n = rotateLeft(n)
+		n.left = t.remove(n.left, element)
+	}
+
+	return n
+}
+
+func rotateLeft(n *node) *node {
+	r := n.right
+	n.right = r.left
+	r.left = n
+
+	return r
+}
+
+func rotateRight(n *node) *node {
+	l := n.left
+	n.left = l.right
+	l.right = n
+
+	return l
+}
+
+func height(n *node) int {
+	if n == nil {
+		return 0
+	}
+
+	return max(height(n.left), height(n.right)) + 1
+}
+
+// Value and Children let the traversal package walk the tree
+func (n *node) Value() int {
+	return n.data
+}
+
+func (n *node) Children() (*node, *node) {
+	return n.left, n.right
+}
+
+func NewTree() *Treap {
+	return &Treap{}
+}